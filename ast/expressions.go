@@ -3,6 +3,7 @@ package ast
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/ollybritton/calclang/token"
@@ -40,6 +41,20 @@ func (il *IntegerLiteral) String() string {
 	return fmt.Sprint(il.Value)
 }
 
+// BigIntegerLiteral represents an integer value too large for an int64 in the AST.
+// Example: `99999999999999999999`
+// General: `{token.INT}`
+type BigIntegerLiteral struct {
+	Tok   token.Token // the token.INT token.
+	Value *big.Int
+}
+
+func (bl *BigIntegerLiteral) expressionNode()    {}
+func (bl *BigIntegerLiteral) Token() token.Token { return bl.Tok }
+func (bl *BigIntegerLiteral) String() string {
+	return bl.Value.String()
+}
+
 // FloatLiteral represents an float value in the AST.
 // Example: `5.5`
 // General: `{token.INT}`
@@ -54,6 +69,103 @@ func (fl *FloatLiteral) String() string {
 	return fmt.Sprint(fl.Value)
 }
 
+// ImaginaryLiteral represents an imaginary number in the AST. The value is the
+// coefficient of i.
+// Example: `2i`
+// General: `{token.IMAG}`
+type ImaginaryLiteral struct {
+	Tok   token.Token // the token.IMAG token.
+	Value float64
+}
+
+func (il *ImaginaryLiteral) expressionNode()    {}
+func (il *ImaginaryLiteral) Token() token.Token { return il.Tok }
+func (il *ImaginaryLiteral) String() string     { return il.Tok.Literal }
+
+// DMSLiteral represents an angle written in degrees, minutes and seconds in the AST.
+// Example: `12°34'56"`
+// General: `{token.DMS}`
+type DMSLiteral struct {
+	Tok     token.Token // the token.DMS token.
+	Degrees float64
+	Minutes float64
+	Seconds float64
+}
+
+func (dl *DMSLiteral) expressionNode()    {}
+func (dl *DMSLiteral) Token() token.Token { return dl.Tok }
+func (dl *DMSLiteral) String() string {
+	return fmt.Sprintf("%v°%v'%v\"", dl.Degrees, dl.Minutes, dl.Seconds)
+}
+
+// Value returns the angle in decimal degrees.
+func (dl *DMSLiteral) Value() float64 {
+	return dl.Degrees + dl.Minutes/60 + dl.Seconds/3600
+}
+
+// MatrixLiteral represents a matrix in the AST, written as a list of rows.
+// Example: `[[1, 2], [3, 4]]`
+// General: `[[{expression}, ...], ...]`
+type MatrixLiteral struct {
+	Tok  token.Token // the token.LBRACKET token.
+	Rows [][]Expression
+}
+
+func (ml *MatrixLiteral) expressionNode()    {}
+func (ml *MatrixLiteral) Token() token.Token { return ml.Tok }
+func (ml *MatrixLiteral) String() string {
+	rows := []string{}
+
+	for _, row := range ml.Rows {
+		elements := []string{}
+		for _, el := range row {
+			elements = append(elements, el.String())
+		}
+
+		rows = append(rows, "["+strings.Join(elements, ", ")+"]")
+	}
+
+	return "[" + strings.Join(rows, ", ") + "]"
+}
+
+// VectorLiteral represents a vector in the AST.
+// Example: `[1, 2, 3]`
+// General: `[{expression}, ...]`
+type VectorLiteral struct {
+	Tok      token.Token // the token.LBRACKET token.
+	Elements []Expression
+}
+
+func (vl *VectorLiteral) expressionNode()    {}
+func (vl *VectorLiteral) Token() token.Token { return vl.Tok }
+func (vl *VectorLiteral) String() string {
+	elements := []string{}
+	for _, el := range vl.Elements {
+		elements = append(elements, el.String())
+	}
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// ListLiteral represents a list of data, such as a column in STAT mode, in the AST.
+// Example: `{1, 2, 3}`
+// General: `{{expression}, ...}`
+type ListLiteral struct {
+	Tok      token.Token // the token.LBRACE token.
+	Elements []Expression
+}
+
+func (ll *ListLiteral) expressionNode()    {}
+func (ll *ListLiteral) Token() token.Token { return ll.Tok }
+func (ll *ListLiteral) String() string {
+	elements := []string{}
+	for _, el := range ll.Elements {
+		elements = append(elements, el.String())
+	}
+
+	return "{" + strings.Join(elements, ", ") + "}"
+}
+
 // PrefixExpression represents an expression involving a prefix operator.
 // Example: `-10`
 // General: `{- or !}{expression}`
@@ -70,6 +182,12 @@ func (pe *PrefixExpression) String() string {
 
 	out.WriteString("(")
 	out.WriteString(pe.Operator)
+
+	// Keyword operators like not need a space, so that `not X` isn't written as `notX`.
+	if token.LookupKeyword(pe.Operator) != token.ILLEGAL {
+		out.WriteString(" ")
+	}
+
 	out.WriteString(pe.Right.String())
 	out.WriteString(")")
 
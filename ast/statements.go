@@ -2,6 +2,7 @@ package ast
 
 import (
 	"bytes"
+	"strings"
 
 	"github.com/ollybritton/calclang/token"
 )
@@ -19,6 +20,10 @@ func (va *VariableAssignment) Token() token.Token { return va.Tok }
 func (va *VariableAssignment) String() string {
 	var out bytes.Buffer
 
+	if va.Name.Constant {
+		out.WriteString("const ")
+	}
+
 	out.WriteString(va.Value.String())
 	out.WriteString(" -> ")
 	out.WriteString(va.Name.String())
@@ -43,6 +48,26 @@ func (ia *InputAssignment) String() string {
 	return out.String()
 }
 
+// MemoryStatement adds the value of an expression to the independent memory M, or
+// subtracts it, like the M+ and M- keys. By itself, M+ or M- uses Ans.
+// Example: `3*4 M+`
+// General: `{expression} M+`, `{expression} M-`, `M+` or `M-`
+type MemoryStatement struct {
+	Tok      token.Token // the token.M_PLUS or token.M_MINUS token.
+	Operator string      // either "+" or "-".
+	Value    Expression  // nil when M+ or M- is used by itself.
+}
+
+func (ms *MemoryStatement) statementNode()     {}
+func (ms *MemoryStatement) Token() token.Token { return ms.Tok }
+func (ms *MemoryStatement) String() string {
+	if ms.Value == nil {
+		return "M" + ms.Operator
+	}
+
+	return ms.Value.String() + " M" + ms.Operator
+}
+
 // ExpressionStatement is a single expression by itself on one line.
 // Example: `{start} a+10 {end}` (where start & end are the start and end of the line)
 // General: `{start}{expression}{end}`
@@ -56,3 +81,45 @@ func (es *ExpressionStatement) Token() token.Token { return es.Tok }
 func (es *ExpressionStatement) String() string {
 	return es.Expression.String()
 }
+
+// Directive represents a line which changes a setting, such as the angle unit.
+// Example: `%deg`
+// General: `%{IDENT}`
+type Directive struct {
+	Tok  token.Token // the token.DIRECTIVE token.
+	Name string      // the name of the directive, without the %.
+}
+
+func (d *Directive) statementNode()     {}
+func (d *Directive) Token() token.Token { return d.Tok }
+func (d *Directive) String() string {
+	return "%" + d.Name
+}
+
+// FunctionDefinition represents the definition of a user-defined function in the AST.
+// Example: `f(X, Y) := X*X + Y`
+// General: `{IDENT}({IDENT}, {IDENT}...) := {expression}`
+type FunctionDefinition struct {
+	Tok        token.Token // the token.DEFINE token.
+	Name       *Identifier
+	Parameters []*Identifier
+	Body       Expression
+}
+
+func (fd *FunctionDefinition) statementNode()     {}
+func (fd *FunctionDefinition) Token() token.Token { return fd.Tok }
+func (fd *FunctionDefinition) String() string {
+	var out bytes.Buffer
+
+	params := []string{}
+	for _, p := range fd.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString(fd.Name.String())
+	out.WriteString("(" + strings.Join(params, ", ") + ")")
+	out.WriteString(" := ")
+	out.WriteString(fd.Body.String())
+
+	return out.String()
+}
@@ -13,13 +13,126 @@ func newError(message string, args ...interface{}) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(message, args...)}
 }
 
+func isError(obj object.Object) bool {
+	if obj != nil {
+		return obj.Type() == object.ERROR_OBJ
+	}
+
+	return false
+}
+
 func init() {
 	Builtins["RANDOM_INT"] = &object.Builtin{Fn: BuiltinRandomInt, Strict: true}
 	Builtins["ROUND"] = &object.Builtin{Fn: BuiltinRound, Strict: true}
-	Builtins["SQRT"] = &object.Builtin{Fn: BuiltinSqrt, Strict: true}
+	Builtins["SQRT"] = &object.Builtin{EnvFn: BuiltinSqrt, Strict: true}
+	Builtins["ABS"] = &object.Builtin{Fn: BuiltinAbs, Strict: true}
 
-	Builtins["P"] = &object.Builtin{Fn: BuiltinPrint, Strict: false}
+	Builtins["ARG"] = &object.Builtin{Fn: BuiltinArg, Strict: true, AngleResult: true}
+	Builtins["CONJG"] = &object.Builtin{Fn: BuiltinConjg, Strict: true}
+	Builtins["REP"] = &object.Builtin{Fn: BuiltinReP, Strict: true}
+	Builtins["IMP"] = &object.Builtin{Fn: BuiltinImP, Strict: true}
+
+	Builtins["P"] = &object.Builtin{EnvFn: BuiltinPrint, Strict: false}
 	Builtins["DELTA"] = &object.Builtin{Fn: BuiltinKronDelta, Strict: false}
 	Builtins["FLOOR"] = &object.Builtin{Fn: BuiltinFloor, Strict: false}
 	Builtins["CEIL"] = &object.Builtin{Fn: BuiltinCeil, Strict: false}
+
+	Builtins["SIN"] = &object.Builtin{Fn: BuiltinSin, Strict: true, AngleArgs: true}
+	Builtins["COS"] = &object.Builtin{Fn: BuiltinCos, Strict: true, AngleArgs: true}
+	Builtins["TAN"] = &object.Builtin{Fn: BuiltinTan, Strict: true, AngleArgs: true}
+	Builtins["ASIN"] = &object.Builtin{Fn: BuiltinAsin, Strict: true, AngleResult: true}
+	Builtins["ACOS"] = &object.Builtin{Fn: BuiltinAcos, Strict: true, AngleResult: true}
+	Builtins["ATAN"] = &object.Builtin{Fn: BuiltinAtan, Strict: true, AngleResult: true}
+
+	Builtins["LN"] = &object.Builtin{Fn: BuiltinLn, Strict: true}
+	Builtins["LOG"] = &object.Builtin{Fn: BuiltinLog, Strict: true}
+	Builtins["EXP"] = &object.Builtin{Fn: BuiltinExp, Strict: true}
+	Builtins["EXP10"] = &object.Builtin{Fn: BuiltinExp10, Strict: true}
+
+	Builtins["SINH"] = &object.Builtin{Fn: BuiltinSinh, Strict: true}
+	Builtins["COSH"] = &object.Builtin{Fn: BuiltinCosh, Strict: true}
+	Builtins["TANH"] = &object.Builtin{Fn: BuiltinTanh, Strict: true}
+	Builtins["ASINH"] = &object.Builtin{Fn: BuiltinAsinh, Strict: true}
+	Builtins["ACOSH"] = &object.Builtin{Fn: BuiltinAcosh, Strict: true}
+	Builtins["ATANH"] = &object.Builtin{Fn: BuiltinAtanh, Strict: true}
+
+	Builtins["POL"] = &object.Builtin{EnvFn: BuiltinPol, Strict: true}
+	Builtins["REC"] = &object.Builtin{EnvFn: BuiltinRec, Strict: true}
+
+	Builtins["SIGMA"] = &object.Builtin{Form: BuiltinSigma, Strict: true}
+	Builtins["Σ"] = Builtins["SIGMA"]
+	Builtins["PRODUCT"] = &object.Builtin{Form: BuiltinProduct, Strict: true}
+	Builtins["Π"] = Builtins["PRODUCT"]
+
+	Builtins["INTEGRATE"] = &object.Builtin{Form: BuiltinIntegrate, Strict: true}
+	Builtins["∫"] = Builtins["INTEGRATE"]
+	Builtins["DERIV"] = &object.Builtin{Form: BuiltinDeriv, Strict: true}
+
+	Builtins["SOLVE"] = &object.Builtin{Form: BuiltinSolve, Strict: true}
+	Builtins["CONV"] = &object.Builtin{Form: BuiltinConv, Strict: true}
+
+	Builtins["GCD"] = &object.Builtin{Fn: BuiltinGcd, Strict: true}
+	Builtins["LCM"] = &object.Builtin{Fn: BuiltinLcm, Strict: true}
+	Builtins["NPR"] = &object.Builtin{Fn: BuiltinNpr, Strict: true}
+	Builtins["NCR"] = &object.Builtin{Fn: BuiltinNcr, Strict: true}
+	Builtins["INT"] = &object.Builtin{Fn: BuiltinInt, Strict: true}
+	Builtins["INTG"] = &object.Builtin{Fn: BuiltinIntg, Strict: true}
+	Builtins["MOD"] = &object.Builtin{Fn: BuiltinMod, Strict: true}
+
+	Builtins["DET"] = &object.Builtin{Fn: BuiltinDet, Strict: true}
+	Builtins["TRN"] = &object.Builtin{Fn: BuiltinTrn, Strict: true}
+	Builtins["INV"] = &object.Builtin{Fn: BuiltinInv, Strict: true}
+	Builtins["IDENTITY"] = &object.Builtin{Fn: BuiltinIdentity, Strict: true}
+
+	Builtins["DOT"] = &object.Builtin{Fn: BuiltinDot, Strict: true}
+	Builtins["CROSS"] = &object.Builtin{Fn: BuiltinCross, Strict: true}
+	Builtins["ANGLE"] = &object.Builtin{Fn: BuiltinAngle, Strict: true, AngleResult: true}
+	Builtins["UNITV"] = &object.Builtin{Fn: BuiltinUnitV, Strict: true}
+
+	Builtins["MEAN"] = &object.Builtin{Fn: BuiltinMean, Strict: true}
+	Builtins["X\u0304"] = Builtins["MEAN"] // x̄
+	Builtins["SIGMAX"] = &object.Builtin{Fn: BuiltinSigmaX, Strict: true}
+	Builtins["SX"] = &object.Builtin{Fn: BuiltinSX, Strict: true}
+	Builtins["SUMX"] = &object.Builtin{Fn: BuiltinSumX, Strict: true}
+	Builtins["SUMX2"] = &object.Builtin{Fn: BuiltinSumX2, Strict: true}
+	Builtins["COUNT"] = &object.Builtin{Fn: BuiltinCount, Strict: true}
+	Builtins["MINX"] = &object.Builtin{Fn: BuiltinMinX, Strict: true}
+	Builtins["MAXX"] = &object.Builtin{Fn: BuiltinMaxX, Strict: true}
+	Builtins["SUM"] = &object.Builtin{Fn: BuiltinSum, Strict: true}
+	Builtins["MIN"] = &object.Builtin{Fn: BuiltinMin, Strict: true}
+	Builtins["MAX"] = &object.Builtin{Fn: BuiltinMax, Strict: true}
+
+	Builtins["LINREG"] = &object.Builtin{Fn: BuiltinLinReg, Strict: true}
+	Builtins["QUADREG"] = &object.Builtin{Fn: BuiltinQuadReg, Strict: true}
+	Builtins["LOGREG"] = &object.Builtin{Fn: BuiltinLogReg, Strict: true}
+	Builtins["EXPREG"] = &object.Builtin{Fn: BuiltinExpReg, Strict: true}
+	Builtins["PWRREG"] = &object.Builtin{Fn: BuiltinPwrReg, Strict: true}
+	Builtins["REGA"] = &object.Builtin{Fn: BuiltinRegA, Strict: true}
+	Builtins["REGB"] = &object.Builtin{Fn: BuiltinRegB, Strict: true}
+	Builtins["REGC"] = &object.Builtin{Fn: BuiltinRegC, Strict: true}
+	Builtins["REGR"] = &object.Builtin{Fn: BuiltinRegR, Strict: true}
+	Builtins["YHAT"] = &object.Builtin{Fn: BuiltinYHat, Strict: true}
+	Builtins["Ŷ"] = Builtins["YHAT"]
+	Builtins["Y\u0302"] = Builtins["YHAT"] // ŷ with a combining circumflex
+	Builtins["XHAT"] = &object.Builtin{Fn: BuiltinXHat, Strict: true}
+	Builtins["X\u0302"] = Builtins["XHAT"] // x̂
+
+	Builtins["SIMUL"] = &object.Builtin{Fn: BuiltinSimul, Strict: true}
+	Builtins["POLY"] = &object.Builtin{Fn: BuiltinPoly, Strict: true}
+	Builtins["VERTEX"] = &object.Builtin{Fn: BuiltinVertex, Strict: true}
+	Builtins["SOL"] = &object.Builtin{Fn: BuiltinSol, Strict: true}
+	Builtins["INEQ"] = &object.Builtin{Form: BuiltinIneq, Strict: true}
+
+	Builtins["NORMPD"] = &object.Builtin{Fn: BuiltinNormPD, Strict: true}
+	Builtins["NORMCD"] = &object.Builtin{Fn: BuiltinNormCD, Strict: true}
+	Builtins["INVN"] = &object.Builtin{Form: BuiltinInvN, Strict: true}
+	Builtins["BINOMPD"] = &object.Builtin{Fn: BuiltinBinomPD, Strict: true}
+	Builtins["BINOMCD"] = &object.Builtin{Fn: BuiltinBinomCD, Strict: true}
+	Builtins["POISSONPD"] = &object.Builtin{Fn: BuiltinPoissonPD, Strict: true}
+	Builtins["POISSONCD"] = &object.Builtin{Fn: BuiltinPoissonCD, Strict: true}
+
+	Builtins["ENG"] = &object.Builtin{Fn: BuiltinEng, Strict: true}
+	Builtins["DMS"] = &object.Builtin{Fn: BuiltinDMS, Strict: true}
+	Builtins["NORM"] = &object.Builtin{Fn: BuiltinNorm, Strict: true}
+	Builtins["DEGREES"] = &object.Builtin{Fn: BuiltinDegrees, Strict: true}
 }
@@ -0,0 +1,44 @@
+package builtins_test
+
+import (
+	"testing"
+
+	"github.com/ollybritton/calclang/evaluator"
+	"github.com/ollybritton/calclang/object"
+	"github.com/stretchr/testify/assert"
+)
+
+// evalTest is an input to evaluate, along with either its expected result or the message
+// of the error it should give.
+type evalTest struct {
+	input    string
+	expected string
+}
+
+// testEval evaluates each input in a new environment, and checks that it gives the expected
+// result, written the way the REPL displays it.
+func testEval(t *testing.T, tests []evalTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		env := object.NewEnvironment()
+
+		result, errs := evaluator.EvalString(tt.input, env)
+		if assert.Empty(t, errs, "unexpected error for input %q", tt.input) {
+			assert.Equal(t, tt.expected, env.Format(result).Inspect(), "wrong result for input %q", tt.input)
+		}
+	}
+}
+
+// testEvalErrors evaluates each input in a new environment, and checks that it gives an
+// error with the expected message.
+func testEvalErrors(t *testing.T, tests []evalTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		_, errs := evaluator.EvalString(tt.input, object.NewEnvironment())
+		if assert.NotEmpty(t, errs, "expected an error for input %q", tt.input) {
+			assert.Equal(t, "ERROR: "+tt.expected, errs[0].Error(), "wrong error for input %q", tt.input)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// INTEGRATION_TOL is the default absolute error INTEGRATE aims for.
+const INTEGRATION_TOL float64 = 1e-10
+
+// MAX_INTEGRATION_INTERVALS is the number of times INTEGRATE will split up the range
+// before giving up with a Time Out error.
+const MAX_INTEGRATION_INTERVALS int = 2000
+
+// SUBSTITUTION_WIDTH is the width of range above which INTEGRATE substitutes the variable,
+// so that it doesn't miss a narrow peak in a huge range.
+const SUBSTITUTION_WIDTH float64 = 1e3
+
+// INTEGRATION_FIGURES is the number of significant figures INTEGRATE rounds its result to.
+const INTEGRATION_FIGURES int = 15
+
+// DERIVATIVE_TOL is the relative error DERIV aims for.
+const DERIVATIVE_TOL float64 = 1e-8
+
+// DERIVATIVE_FIGURES is the number of significant figures DERIV rounds its result to. The
+// derivative is only accurate to around DERIVATIVE_TOL, so this hides the noise beyond
+// that, like the calculator's 10 digit display.
+const DERIVATIVE_FIGURES int = 10
+
+// Nodes and weights for the 7-point Gauss and 15-point Kronrod quadrature rules on
+// [-1, 1]. Only the non-negative nodes are listed, as the rules are symmetric. The Gauss
+// nodes are every other Kronrod node.
+var (
+	kronrodNodes = []float64{
+		0.991455371120812639206854697526329,
+		0.949107912342758524526189684047851,
+		0.864864423359769072789712788640926,
+		0.741531185599394439863864773280788,
+		0.586087235467691130294144845693013,
+		0.405845151377397166906606412076961,
+		0.207784955007898467600689403773245,
+		0.000000000000000000000000000000000,
+	}
+
+	kronrodWeights = []float64{
+		0.022935322010529224963732008058970,
+		0.063092092629978553290700663189204,
+		0.104790010322250183839876322541518,
+		0.140653259715525918745189590510238,
+		0.169004726639267902826583426598550,
+		0.190350578064785409913256402421014,
+		0.204432940075298892414161999234649,
+		0.209482141084727828012999174891714,
+	}
+
+	gaussWeights = []float64{
+		0.129484966168869693270611432679082,
+		0.279705391489276667901467771423780,
+		0.381830050505118944950369775488975,
+		0.417959183673469387755102040816327,
+	}
+)
+
+// BuiltinIntegrate finds the definite integral of an expression with respect to a
+// variable between two bounds, like ∫( on the calculator. For example,
+// INTEGRATE(X*X, X, 0, 3) is 9. An optional fifth argument sets the tolerance.
+// It uses adaptive Gauss–Kronrod quadrature, and gives a Time Out error if the integral
+// doesn't converge.
+func BuiltinIntegrate(in object.Interpreter, env *object.Environment, args ...ast.Expression) object.Object {
+	if len(args) != 4 && len(args) != 5 {
+		return newError("wrong number of arguments. got=%d, want=4 or 5", len(args))
+	}
+
+	variable, err := variableArgument("INTEGRATE", 1, args[1])
+	if err != nil {
+		return err
+	}
+
+	a, err := evalFloatArgument(in, env, "INTEGRATE", 2, args[2])
+	if err != nil {
+		return err
+	}
+
+	b, err := evalFloatArgument(in, env, "INTEGRATE", 3, args[3])
+	if err != nil {
+		return err
+	}
+
+	tol := INTEGRATION_TOL
+	if len(args) == 5 {
+		tol, err = evalFloatArgument(in, env, "INTEGRATE", 4, args[4])
+		if err != nil {
+			return err
+		}
+
+		if tol <= 0 {
+			return newError("ArgumentERROR: tolerance given to `INTEGRATE` must be positive, got=%v", tol)
+		}
+	}
+
+	if a == b {
+		return &object.Float{Value: 0}
+	}
+
+	f := function(in, env, "INTEGRATE", args[0], variable)
+
+	result, err := integrateRange(f, a, b, tol)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(object.RoundSignificant(result, INTEGRATION_FIGURES))
+}
+
+// BuiltinDeriv finds the derivative of an expression with respect to a variable at a
+// point, like d/dx( on the calculator. For example, DERIV(X*X, X, 3) is 6.
+// It uses central differences with Richardson extrapolation, and gives a Time Out error
+// if the derivative doesn't converge.
+func BuiltinDeriv(in object.Interpreter, env *object.Environment, args ...ast.Expression) object.Object {
+	if len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=3", len(args))
+	}
+
+	variable, err := variableArgument("DERIV", 1, args[1])
+	if err != nil {
+		return err
+	}
+
+	x, err := evalFloatArgument(in, env, "DERIV", 2, args[2])
+	if err != nil {
+		return err
+	}
+
+	f := function(in, env, "DERIV", args[0], variable)
+
+	result, err := differentiate(f, x)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(object.RoundSignificant(result, DERIVATIVE_FIGURES))
+}
+
+// interval is a part of the range being integrated, along with its estimated integral and
+// error.
+type interval struct {
+	a, b     float64
+	integral float64
+	err      float64
+}
+
+// integrateRange finds the integral of f between a and b. Huge ranges are split at the
+// point nearest 0, and each side is integrated after substituting x = p ± (1-t)/t. This
+// spreads the nodes out so that they are closest together near p, rather than evenly over
+// the range where they could all miss a peak such as EXP(-X*X) and wrongly agree on 0.
+func integrateRange(f func(float64) (float64, *object.Error), a, b, tol float64) (float64, *object.Error) {
+	if a > b {
+		result, err := integrateRange(f, b, a, tol)
+		return -result, err
+	}
+
+	if b-a <= SUBSTITUTION_WIDTH {
+		return integrate(f, a, b, tol)
+	}
+
+	p := math.Max(a, math.Min(b, 0))
+	total := 0.0
+
+	for _, side := range []struct{ sign, width float64 }{{-1, p - a}, {1, b - p}} {
+		if side.width == 0 {
+			continue
+		}
+
+		sign := side.sign
+		substituted := func(t float64) (float64, *object.Error) {
+			y, err := f(p + sign*(1-t)/t)
+			return y / (t * t), err
+		}
+
+		result, err := integrate(substituted, 1/(1+side.width), 1, tol/2)
+		if err != nil {
+			return 0, err
+		}
+
+		total += result
+	}
+
+	return total, nil
+}
+
+// integrate finds the integral of f between a and b to within an absolute tolerance. The
+// interval with the largest error is repeatedly split in half until the total error is
+// small enough.
+func integrate(f func(float64) (float64, *object.Error), a, b, tol float64) (float64, *object.Error) {
+	first, err := gaussKronrod(f, a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	intervals := []interval{first}
+
+	for len(intervals) < MAX_INTEGRATION_INTERVALS {
+		total, totalErr, worst := 0.0, 0.0, 0
+
+		for i, iv := range intervals {
+			total += iv.integral
+			totalErr += iv.err
+
+			if iv.err > intervals[worst].err {
+				worst = i
+			}
+		}
+
+		if totalErr <= math.Max(tol, tol*math.Abs(total)) {
+			return total, nil
+		}
+
+		iv := intervals[worst]
+		mid := (iv.a + iv.b) / 2
+
+		left, err := gaussKronrod(f, iv.a, mid)
+		if err != nil {
+			return 0, err
+		}
+
+		right, err := gaussKronrod(f, mid, iv.b)
+		if err != nil {
+			return 0, err
+		}
+
+		intervals[worst] = left
+		intervals = append(intervals, right)
+	}
+
+	return 0, newError("Time Out: integral did not converge")
+}
+
+// gaussKronrod estimates the integral of f between a and b using the 15-point Kronrod
+// rule, using the difference from the embedded 7-point Gauss rule as the error.
+func gaussKronrod(f func(float64) (float64, *object.Error), a, b float64) (interval, *object.Error) {
+	centre := (a + b) / 2
+	halfWidth := (b - a) / 2
+
+	kronrod, gauss := 0.0, 0.0
+
+	for i, node := range kronrodNodes {
+		var sum float64
+
+		if node == 0 {
+			y, err := f(centre)
+			if err != nil {
+				return interval{}, err
+			}
+
+			sum = y
+		} else {
+			left, err := f(centre - halfWidth*node)
+			if err != nil {
+				return interval{}, err
+			}
+
+			right, err := f(centre + halfWidth*node)
+			if err != nil {
+				return interval{}, err
+			}
+
+			sum = left + right
+		}
+
+		kronrod += kronrodWeights[i] * sum
+
+		if i%2 == 1 {
+			gauss += gaussWeights[i/2] * sum
+		}
+	}
+
+	return interval{
+		a:        a,
+		b:        b,
+		integral: kronrod * halfWidth,
+		err:      math.Abs((kronrod - gauss) * halfWidth),
+	}, nil
+}
+
+// differentiate finds the derivative of f at x. Central differences are calculated with
+// smaller and smaller steps, and Richardson extrapolation is used to cancel out the
+// error terms until two successive estimates agree.
+func differentiate(f func(float64) (float64, *object.Error), x float64) (float64, *object.Error) {
+	const steps = 10
+
+	h := 0.1 * math.Max(1, math.Abs(x))
+	table := make([][]float64, steps)
+
+	best, bestErr := 0.0, math.Inf(1)
+
+	for i := 0; i < steps; i++ {
+		right, err := f(x + h)
+		if err != nil {
+			return 0, err
+		}
+
+		left, err := f(x - h)
+		if err != nil {
+			return 0, err
+		}
+
+		table[i] = make([]float64, i+1)
+		table[i][0] = (right - left) / (2 * h)
+
+		factor := 1.0
+		for j := 1; j <= i; j++ {
+			factor *= 4
+			table[i][j] = table[i][j-1] + (table[i][j-1]-table[i-1][j-1])/(factor-1)
+
+			if diff := math.Abs(table[i][j] - table[i-1][j-1]); diff < bestErr {
+				best, bestErr = table[i][j], diff
+			}
+		}
+
+		if bestErr <= DERIVATIVE_TOL*math.Max(1, math.Abs(best)) {
+			return best, nil
+		}
+
+		h /= 2
+	}
+
+	return 0, newError("Time Out: derivative did not converge")
+}
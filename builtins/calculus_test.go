@@ -0,0 +1,35 @@
+package builtins_test
+
+import "testing"
+
+func TestCalculus(t *testing.T) {
+	testEval(t, []evalTest{
+		{"INTEGRATE(X*X, X, 0, 3)", "9"},
+		{"INTEGRATE(X*X, X, 3, 0)", "-9"},
+		{"INTEGRATE(X, X, 2, 2)", "0"},
+		{"%rad\nINTEGRATE(SIN(X), X, 0, pi)", "2"},
+		{"INTEGRATE(1/X, X, 1, e)", "1"},
+		{"INTEGRATE(SQRT(X), X, 0, 1, 1e-4)", "0.666671425099943"},
+
+		// Huge ranges, where evenly spaced nodes would all miss the peak.
+		{"INTEGRATE(EXP(-X*X), X, -1e9, 1e9)", "1.77245385090552"},
+		{"INTEGRATE(EXP(-X*X), X, 0, 1e9)", "0.886226925452758"},
+		{"INTEGRATE(EXP(-X), X, 0, 1e6)", "1"},
+
+		{"DERIV(X*X, X, 3)", "6"},
+		{"DERIV(X*X*X, X, -2)", "12"},
+		{"%rad\nDERIV(SIN(X), X, 0)", "1"},
+		{"DERIV(EXP(X), X, 1)", "2.718281828"},
+		{"DERIV(1/X, X, 2)", "-0.25"},
+	})
+}
+
+func TestCalculusErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"INTEGRATE(SQRT(X), X, 0, 1, 1e-300)", "Time Out: integral did not converge"},
+		{"DERIV(SIN(1/X), X, 1e-4)", "Time Out: derivative did not converge"},
+		{"%rad\nDERIV(1/X, X, 0)", "Time Out: derivative did not converge"},
+		{"INTEGRATE(X, X, 0, 1, 0)", "ArgumentERROR: tolerance given to `INTEGRATE` must be positive, got=0"},
+		{"INTEGRATE(X, X, 0, 1, -1)", "ArgumentERROR: tolerance given to `INTEGRATE` must be positive, got=-1"},
+	})
+}
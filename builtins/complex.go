@@ -0,0 +1,63 @@
+package builtins
+
+import (
+	"math/cmplx"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// BuiltinArg finds the argument of a complex number, the angle it makes with the positive
+// real axis. The result is converted into the angle unit.
+func BuiltinArg(args ...object.Object) object.Object {
+	z, err := complexArgument("ARG", args)
+	if err != nil {
+		return err
+	}
+
+	return &object.Float{Value: cmplx.Phase(z)}
+}
+
+// BuiltinConjg finds the complex conjugate of a number, such as 3-4i for 3+4i.
+func BuiltinConjg(args ...object.Object) object.Object {
+	z, err := complexArgument("CONJG", args)
+	if err != nil {
+		return err
+	}
+
+	return object.NewComplex(cmplx.Conj(z))
+}
+
+// BuiltinReP finds the real part of a complex number.
+func BuiltinReP(args ...object.Object) object.Object {
+	z, err := complexArgument("REP", args)
+	if err != nil {
+		return err
+	}
+
+	return &object.Float{Value: real(z)}
+}
+
+// BuiltinImP finds the imaginary part of a complex number.
+func BuiltinImP(args ...object.Object) object.Object {
+	z, err := complexArgument("IMP", args)
+	if err != nil {
+		return err
+	}
+
+	return &object.Float{Value: imag(z)}
+}
+
+// complexArgument checks that a builtin has been given a single number, and converts it
+// into a complex number.
+func complexArgument(name string, args []object.Object) (complex128, *object.Error) {
+	if len(args) != 1 {
+		return 0, newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	z, ok := object.ToComplex(args[0])
+	if !ok {
+		return 0, newError("argument to `%s` not supported, got=%s", name, args[0].Type())
+	}
+
+	return z, nil
+}
@@ -0,0 +1,72 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// BuiltinPol converts rectangular coordinates (x, y) into polar coordinates (r, θ), with
+// θ in the current angle unit. Like Pol( on the calculator, r is stored into the X
+// register and θ into the Y register, and r is returned.
+func BuiltinPol(env *object.Environment, args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	x, err := floatArgument("POL", args[:1])
+	if err != nil {
+		return err
+	}
+
+	y, err := floatArgument("POL", args[1:])
+	if err != nil {
+		return err
+	}
+
+	r := &object.Float{Value: math.Hypot(x, y)}
+	theta := &object.Float{Value: env.AngleUnit().FromRadians(math.Atan2(y, x))}
+
+	return storeCoordinates(env, r, theta)
+}
+
+// BuiltinRec converts polar coordinates (r, θ), with θ in the current angle unit, into
+// rectangular coordinates (x, y). Like Rec( on the calculator, x is stored into the X
+// register and y into the Y register, and x is returned.
+func BuiltinRec(env *object.Environment, args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	r, err := floatArgument("REC", args[:1])
+	if err != nil {
+		return err
+	}
+
+	theta, err := floatArgument("REC", args[1:])
+	if err != nil {
+		return err
+	}
+
+	radians := env.AngleUnit().ToRadians(theta)
+
+	x := &object.Float{Value: r * exactCos(radians)}
+	y := &object.Float{Value: r * exactSin(radians)}
+
+	return storeCoordinates(env, x, y)
+}
+
+// storeCoordinates stores a pair of coordinates into the X and Y registers, and returns
+// the first one. The registers belong to the outermost environment, so they are kept
+// even when POL or REC is used inside a function or a sum.
+func storeCoordinates(env *object.Environment, first, second object.Object) object.Object {
+	if err := env.SetGlobal("X", first); err.Type() == object.ERROR_OBJ {
+		return err
+	}
+
+	if err := env.SetGlobal("Y", second); err.Type() == object.ERROR_OBJ {
+		return err
+	}
+
+	return first
+}
@@ -0,0 +1,82 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// BuiltinEng converts a number so that it is displayed in engineering notation, like the
+// ENG key on the calculator.
+func BuiltinEng(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	switch object.Value(args[0]).(type) {
+	case *object.Integer, *object.Float:
+		return object.WithDisplay(object.Value(args[0]), object.DISPLAY_ENG)
+	default:
+		return newError("argument to `ENG` not supported, got=%s", args[0].Type())
+	}
+}
+
+// BuiltinDMS converts a number of decimal degrees so that it is displayed in sexagesimal
+// notation, like the °'" key on the calculator.
+func BuiltinDMS(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	switch object.Value(args[0]).(type) {
+	case *object.Integer, *object.Float:
+		return object.WithDisplay(object.Value(args[0]), object.DISPLAY_DMS)
+	default:
+		return newError("argument to `DMS` not supported, got=%s", args[0].Type())
+	}
+}
+
+// BuiltinNorm converts a number back to ordinary notation, undoing ENG(x) or DMS(x).
+func BuiltinNorm(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	switch val := object.Value(args[0]).(type) {
+	case *object.Integer:
+		return val
+	case *object.Float:
+		return &object.Float{Value: val.Value}
+	default:
+		return newError("argument to `NORM` not supported, got=%s", args[0].Type())
+	}
+}
+
+// BuiltinDegrees converts an angle given as separate degrees, minutes and seconds into
+// decimal degrees. The seconds are optional.
+func BuiltinDegrees(args ...object.Object) object.Object {
+	if len(args) != 2 && len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=2 or 3", len(args))
+	}
+
+	parts := make([]float64, 3)
+
+	for i, arg := range args {
+		switch arg := object.Value(arg).(type) {
+		case *object.Integer:
+			parts[i] = float64(arg.Value)
+		case *object.Float:
+			parts[i] = arg.Value
+		default:
+			return newError("argument %d to `DEGREES` not supported, got=%s", i+1, arg.Type())
+		}
+	}
+
+	// The sign of the degrees applies to the angle as a whole, so -12°30' is -12.5.
+	value := math.Abs(parts[0]) + parts[1]/60 + parts[2]/3600
+	if math.Signbit(parts[0]) {
+		value = -value
+	}
+
+	return &object.Float{Value: value}
+}
@@ -0,0 +1,51 @@
+package builtins_test
+
+import "testing"
+
+func TestEngineering(t *testing.T) {
+	testEval(t, []evalTest{
+		{"ENG(4700)", "4.7k"},
+		{"ENG(0.0000047)", "4.7μ"},
+		{"ENG(1e15)", "1e15"},
+		{"ENG(4.7k)", "4.7k"},
+		{"NORM(ENG(4700))", "4700"},
+		{"ENG(4700) + 1", "4701"},
+	})
+}
+
+func TestEngineeringErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"ENG([1, 2])", "argument to `ENG` not supported, got=VECTOR"},
+		{"ENG(1+i)", "argument to `ENG` not supported, got=COMPLEX"},
+	})
+}
+
+func TestDMS(t *testing.T) {
+	testEval(t, []evalTest{
+		{"DMS(12.5)", `12°30'0"`},
+		{"DMS(-12.5)", `-12°30'0"`},
+		{"DMS(0.99999999)", `1°0'0"`},
+		{`12°34'56"`, "12.582222222222223"},
+		{`12°30' + 1`, "13.5"},
+		{`%deg` + "\n" + `SIN(30°)`, "0.5"},
+
+		{"DEGREES(12, 30)", "12.5"},
+		{"DEGREES(-12, 30)", "-12.5"},
+		{"DEGREES(12, 30.5)", "12.508333333333333"},
+		{"DEGREES(12, 34, 56)", "12.582222222222223"},
+
+		{`DMS(12°34'56")`, `12°34'56"`},
+		{"DMS(DEGREES(12, 34, 56))", `12°34'56"`},
+		{`DEGREES(12, 34, 56) - 12°34'56"`, "0"},
+		{"NORM(DMS(12.5))", "12.5"},
+		{"DMS(12.5) * 2", "25"},
+	})
+}
+
+func TestDMSErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"DMS([1, 2])", "argument to `DMS` not supported, got=VECTOR"},
+		{"DEGREES(1)", "wrong number of arguments. got=1, want=2 or 3"},
+		{"DEGREES(1, [1, 2])", "argument 2 to `DEGREES` not supported, got=VECTOR"},
+	})
+}
@@ -0,0 +1,455 @@
+package builtins
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// DIST_FIGURES is the number of significant figures the results of the distribution
+// functions are shown to, matching the calculator's display. The results keep their full
+// precision, so they can be used in later calculations.
+const DIST_FIGURES int = 10
+
+// MAX_DISTRIBUTION_TERMS is the largest number of probabilities BINOMCD and POISSONCD will
+// add up before giving up with a MathERROR, so that huge arguments don't hang the program.
+const MAX_DISTRIBUTION_TERMS float64 = 1000000
+
+// Tail is the region of a normal distribution that the area given to INVN covers.
+type Tail string
+
+// Definition of tails.
+const (
+	TAIL_LEFT   Tail = "LEFT"   // the area below x
+	TAIL_RIGHT  Tail = "RIGHT"  // the area above x
+	TAIL_CENTER Tail = "CENTER" // the area between μ-(x-μ) and x, centred on the mean
+)
+
+// BuiltinNormPD finds the probability density of a normal distribution at x, as in
+// NORMPD(x, σ, μ). σ and μ can be left out to use the standard normal distribution.
+func BuiltinNormPD(args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=1 or 3", len(args))
+	}
+
+	return distribute("NORMPD", args, func(v []float64) (float64, *object.Error) {
+		x, sigma, mu := v[0], 1.0, 0.0
+		if len(v) == 3 {
+			sigma, mu = v[1], v[2]
+		}
+
+		if sigma <= 0 {
+			return 0, newError("ArgumentERROR: `NORMPD` needs σ > 0, got=%s", inspectFloat(sigma))
+		}
+
+		z := (x - mu) / sigma
+		return math.Exp(-z*z/2) / (sigma * math.Sqrt(2*math.Pi)), nil
+	})
+}
+
+// BuiltinNormCD finds the probability that a normally distributed value lies between a
+// lower and upper bound, as in NORMCD(lower, upper, σ, μ). σ and μ can be left out to use
+// the standard normal distribution.
+func BuiltinNormCD(args ...object.Object) object.Object {
+	if len(args) != 2 && len(args) != 4 {
+		return newError("wrong number of arguments. got=%d, want=2 or 4", len(args))
+	}
+
+	return distribute("NORMCD", args, func(v []float64) (float64, *object.Error) {
+		lower, upper, sigma, mu := v[0], v[1], 1.0, 0.0
+		if len(v) == 4 {
+			sigma, mu = v[2], v[3]
+		}
+
+		if sigma <= 0 {
+			return 0, newError("ArgumentERROR: `NORMCD` needs σ > 0, got=%s", inspectFloat(sigma))
+		}
+
+		if lower > upper {
+			return 0, newError("ArgumentERROR: `NORMCD` needs lower ≤ upper, got=%s and %s", inspectFloat(lower), inspectFloat(upper))
+		}
+
+		return normalArea((lower-mu)/sigma, (upper-mu)/sigma), nil
+	})
+}
+
+// BuiltinInvN finds the value x that a given area of a normal distribution lies below, as
+// in INVN(area, σ, μ). σ and μ can be left out to use the standard normal distribution.
+// A final argument of LEFT, RIGHT or CENTER picks the tail the area covers, which is LEFT
+// by default. The CENTER tail has two values, which are returned as a list.
+func BuiltinInvN(in object.Interpreter, env *object.Environment, args ...ast.Expression) object.Object {
+	tail := TAIL_LEFT
+
+	if len(args) > 0 {
+		if ident, ok := args[len(args)-1].(*ast.Identifier); ok {
+			if t, ok := parseTail(ident.Value); ok {
+				tail = t
+				args = args[:len(args)-1]
+			}
+		}
+	}
+
+	if len(args) != 1 && len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=1 or 3, and optionally LEFT, RIGHT or CENTER", len(args))
+	}
+
+	values := make([]object.Object, len(args))
+	for i, arg := range args {
+		values[i] = in.Eval(arg, env)
+		if isError(values[i]) {
+			return values[i]
+		}
+	}
+
+	if tail == TAIL_CENTER {
+		return invNCenter(values)
+	}
+
+	return distribute("INVN", values, func(v []float64) (float64, *object.Error) {
+		area, sigma, mu := v[0], 1.0, 0.0
+		if len(v) == 3 {
+			sigma, mu = v[1], v[2]
+		}
+
+		if err := invNArguments(area, sigma); err != nil {
+			return 0, err
+		}
+
+		if tail == TAIL_RIGHT {
+			area = 1 - area
+		}
+
+		return mu + sigma*inverseNormal(area), nil
+	})
+}
+
+// BuiltinBinomPD finds the probability of exactly x successes in N trials which each
+// succeed with probability p, as in BINOMPD(x, N, p).
+func BuiltinBinomPD(args ...object.Object) object.Object {
+	if len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=3", len(args))
+	}
+
+	return distribute("BINOMPD", args, func(v []float64) (float64, *object.Error) {
+		if err := binomialArguments("BINOMPD", v); err != nil {
+			return 0, err
+		}
+
+		return binomialPD(v[0], v[1], v[2]), nil
+	})
+}
+
+// BuiltinBinomCD finds the probability of at most x successes in N trials which each
+// succeed with probability p, as in BINOMCD(x, N, p).
+func BuiltinBinomCD(args ...object.Object) object.Object {
+	if len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=3", len(args))
+	}
+
+	return distribute("BINOMCD", args, func(v []float64) (float64, *object.Error) {
+		if err := binomialArguments("BINOMCD", v); err != nil {
+			return 0, err
+		}
+
+		x, n, p := v[0], v[1], v[2]
+		if x >= n {
+			return 1, nil
+		}
+
+		return cumulative(x, n*p, func(k float64) float64 { return binomialPD(k, n, p) })
+	})
+}
+
+// BuiltinPoissonPD finds the probability of exactly x events happening when λ are
+// expected, as in POISSONPD(x, λ).
+func BuiltinPoissonPD(args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	return distribute("POISSONPD", args, func(v []float64) (float64, *object.Error) {
+		if err := poissonArguments("POISSONPD", v); err != nil {
+			return 0, err
+		}
+
+		return poissonPD(v[0], v[1]), nil
+	})
+}
+
+// BuiltinPoissonCD finds the probability of at most x events happening when λ are
+// expected, as in POISSONCD(x, λ).
+func BuiltinPoissonCD(args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	return distribute("POISSONCD", args, func(v []float64) (float64, *object.Error) {
+		if err := poissonArguments("POISSONCD", v); err != nil {
+			return 0, err
+		}
+
+		x, lambda := v[0], v[1]
+		return cumulative(x, lambda, func(k float64) float64 { return poissonPD(k, lambda) })
+	})
+}
+
+// distribute calls a distribution function with the values of its arguments. If any of
+// the arguments are lists, the function is called once for each element, with numbers
+// being used for every call, and the results are returned as a list.
+func distribute(name string, args []object.Object, fn func(v []float64) (float64, *object.Error)) object.Object {
+	lists := make([][]float64, len(args))
+	isList := make([]bool, len(args))
+	length := -1
+
+	for i, arg := range args {
+		switch val := object.Value(arg).(type) {
+		case *object.List:
+			if length != -1 && len(val.Values) != length {
+				return newError("Dimension ERROR: lists given to `%s` must have the same number of elements, got=%d and %d", name, length, len(val.Values))
+			}
+
+			lists[i] = val.Values
+			isList[i] = true
+			length = len(val.Values)
+		case *object.Integer, *object.Float, *object.BigInteger:
+			x, err := floatArgument(name, []object.Object{val})
+			if err != nil {
+				return err
+			}
+
+			lists[i] = []float64{x}
+		default:
+			return newError("argument %d to `%s` not supported, got=%s", i+1, name, arg.Type())
+		}
+	}
+
+	call := func(j int) (float64, *object.Error) {
+		v := make([]float64, len(lists))
+		for i, values := range lists {
+			if isList[i] {
+				v[i] = values[j]
+			} else {
+				v[i] = values[0]
+			}
+		}
+
+		result, err := fn(v)
+		if err != nil {
+			return 0, err
+		}
+
+		if math.IsNaN(result) || math.IsInf(result, 0) {
+			return 0, newError("MathERROR")
+		}
+
+		return result, nil
+	}
+
+	if length == -1 {
+		result, err := call(0)
+		if err != nil {
+			return err
+		}
+
+		return &object.Float{Value: result, Figures: DIST_FIGURES}
+	}
+
+	results := &object.List{Values: make([]float64, length), Figures: DIST_FIGURES}
+	for j := range results.Values {
+		result, err := call(j)
+		if err != nil {
+			return err
+		}
+
+		results.Values[j] = result
+	}
+
+	return results
+}
+
+// invNCenter finds the two values that a given area of a normal distribution lies
+// between, centred on the mean.
+func invNCenter(args []object.Object) object.Object {
+	v := make([]float64, len(args))
+	for i, arg := range args {
+		if object.Value(arg).Type() == object.LIST_OBJ {
+			return newError("ArgumentERROR: `INVN` can't use lists with the CENTER tail")
+		}
+
+		x, err := floatArgument("INVN", []object.Object{arg})
+		if err != nil {
+			return err
+		}
+
+		v[i] = x
+	}
+
+	area, sigma, mu := v[0], 1.0, 0.0
+	if len(v) == 3 {
+		sigma, mu = v[1], v[2]
+	}
+
+	if err := invNArguments(area, sigma); err != nil {
+		return err
+	}
+
+	z := inverseNormal((1 + area) / 2)
+
+	return &object.List{Values: []float64{mu - sigma*z, mu + sigma*z}, Figures: DIST_FIGURES}
+}
+
+// invNArguments checks the area and σ given to INVN.
+func invNArguments(area, sigma float64) *object.Error {
+	if area <= 0 || area >= 1 {
+		return newError("ArgumentERROR: `INVN` needs 0 < area < 1, got=%s", inspectFloat(area))
+	}
+
+	if sigma <= 0 {
+		return newError("ArgumentERROR: `INVN` needs σ > 0, got=%s", inspectFloat(sigma))
+	}
+
+	return nil
+}
+
+// binomialArguments checks that x and N are whole numbers, N isn't negative and p is a
+// probability.
+func binomialArguments(name string, v []float64) *object.Error {
+	x, n, p := v[0], v[1], v[2]
+
+	if x != math.Trunc(x) {
+		return newError("ArgumentERROR: `%s` needs a whole number of successes, got=%s", name, inspectFloat(x))
+	}
+
+	if n != math.Trunc(n) || n < 0 {
+		return newError("ArgumentERROR: `%s` needs a whole number of trials N ≥ 0, got=%s", name, inspectFloat(n))
+	}
+
+	if p < 0 || p > 1 {
+		return newError("ArgumentERROR: `%s` needs 0 ≤ p ≤ 1, got=%s", name, inspectFloat(p))
+	}
+
+	return nil
+}
+
+// poissonArguments checks that x is a whole number and λ is positive.
+func poissonArguments(name string, v []float64) *object.Error {
+	x, lambda := v[0], v[1]
+
+	if x != math.Trunc(x) {
+		return newError("ArgumentERROR: `%s` needs a whole number of events, got=%s", name, inspectFloat(x))
+	}
+
+	if lambda <= 0 {
+		return newError("ArgumentERROR: `%s` needs λ > 0, got=%s", name, inspectFloat(lambda))
+	}
+
+	return nil
+}
+
+// parseTail parses the name of a tail given to INVN, such as LEFT or left.
+func parseTail(name string) (Tail, bool) {
+	switch tail := Tail(strings.ToUpper(name)); tail {
+	case TAIL_LEFT, TAIL_RIGHT, TAIL_CENTER:
+		return tail, true
+	default:
+		return "", false
+	}
+}
+
+// normalArea finds the area of the standard normal distribution between two values. The
+// calculation uses whichever tail avoids subtracting numbers close to 1.
+func normalArea(lower, upper float64) float64 {
+	switch {
+	case lower >= 0:
+		return (math.Erfc(lower/math.Sqrt2) - math.Erfc(upper/math.Sqrt2)) / 2
+	case upper <= 0:
+		return (math.Erfc(-upper/math.Sqrt2) - math.Erfc(-lower/math.Sqrt2)) / 2
+	default:
+		return (math.Erf(upper/math.Sqrt2) - math.Erf(lower/math.Sqrt2)) / 2
+	}
+}
+
+// inverseNormal finds the value that a given area of the standard normal distribution lies
+// below.
+func inverseNormal(area float64) float64 {
+	return -math.Sqrt2 * math.Erfcinv(2*area)
+}
+
+// binomialPD finds the probability of exactly x successes in n trials, using logarithms
+// so that large numbers of trials don't overflow.
+func binomialPD(x, n, p float64) float64 {
+	if x < 0 || x > n {
+		return 0
+	}
+
+	switch {
+	case p == 0:
+		return delta(x, 0)
+	case p == 1:
+		return delta(x, n)
+	}
+
+	return math.Exp(lnChoose(n, x) + x*math.Log(p) + (n-x)*math.Log1p(-p))
+}
+
+// poissonPD finds the probability of exactly x events when λ are expected, using
+// logarithms so that large values of x don't overflow.
+func poissonPD(x, lambda float64) float64 {
+	if x < 0 {
+		return 0
+	}
+
+	lgamma, _ := math.Lgamma(x + 1)
+	return math.Exp(x*math.Log(lambda) - lambda - lgamma)
+}
+
+// cumulative adds up the probabilities of a discrete distribution from 0 to x. Terms more
+// than 40 standard deviations below the mean are too small to matter, so the sum starts
+// there, using √mean as the standard deviation, which is at least as large as that of a
+// binomial or Poisson distribution. Once past the mean, it stops as soon as the terms
+// become too small to change the sum. It gives a MathERROR if it would need more than
+// MAX_DISTRIBUTION_TERMS terms.
+func cumulative(x, mean float64, pd func(k float64) float64) (float64, *object.Error) {
+	sum := 0.0
+	start := math.Max(0, math.Floor(mean-40*math.Sqrt(mean)))
+
+	for k := start; k <= x; k++ {
+		if k-start >= MAX_DISTRIBUTION_TERMS {
+			return 0, newError("MathERROR")
+		}
+
+		term := pd(k)
+		if k > mean && term < sum*1e-17 {
+			break
+		}
+
+		sum += term
+	}
+
+	return math.Min(sum, 1), nil
+}
+
+// lnChoose finds the natural logarithm of nCr.
+func lnChoose(n, r float64) float64 {
+	a, _ := math.Lgamma(n + 1)
+	b, _ := math.Lgamma(r + 1)
+	c, _ := math.Lgamma(n - r + 1)
+
+	return a - b - c
+}
+
+// delta returns 1 if two values are equal and 0 otherwise.
+func delta(a, b float64) float64 {
+	if a == b {
+		return 1
+	}
+
+	return 0
+}
+
+// inspectFloat writes a float the way it would be displayed.
+func inspectFloat(x float64) string {
+	return (&object.Float{Value: x}).Inspect()
+}
@@ -0,0 +1,65 @@
+package builtins_test
+
+import "testing"
+
+// The expected values are what the fx-991EX displays, to 10 significant figures.
+func TestDistributions(t *testing.T) {
+	testEval(t, []evalTest{
+		{"NORMPD(36, 2, 35)", "0.1760326634"},
+		{"NORMPD(0)", "0.3989422804"},
+		{"NORMPD({1, 2}, 1, 0)", "{0.2419707245, 0.05399096651}"},
+
+		{"NORMCD(-1, 1)", "0.6826894921"},
+		{"NORMCD(-1E99, 36, 2, 35)", "0.6914624613"},
+		{"NORMCD(5, 1E99)", "0.0000002866515719"},
+
+		{"INVN(0.975)", "1.959963985"},
+		{"INVN(0.975, 2, 35)", "38.91992797"},
+		{"INVN(0.025, RIGHT)", "1.959963985"},
+		{"INVN(0.95, 2, 35, CENTER)", "{31.08007203, 38.91992797}"},
+		{"INVN({0.1, 0.5, 0.9})", "{-1.281551566, 0, 1.281551566}"},
+
+		{"BINOMPD(5, 10, 0.5)", "0.24609375"},
+		{"BINOMPD(7, 20, 0.3)", "0.1642619852"},
+		{"BINOMPD({0, 1, 2, 3}, 3, 0.5)", "{0.125, 0.375, 0.375, 0.125}"},
+		{"BINOMCD(5, 10, 0.5)", "0.623046875"},
+		{"BINOMCD(7, 20, 0.3)", "0.7722717974"},
+		{"BINOMCD(20, 20, 0.3)", "1"},
+		{"BINOMCD(500000, 1000000, 0.5)", "0.5003989417"},
+
+		{"POISSONPD(3, 2.5)", "0.2137630172"},
+		{"POISSONCD(3, 2.5)", "0.7575761331"},
+		{"POISSONCD({0, 1}, 2.5)", "{0.08208499862, 0.2872974952}"},
+		{"POISSONCD(1000, 1000)", "0.5084093672"},
+		{"POISSONCD(1000000, 1000000)", "0.5002659614"},
+		{"POISSONCD(1e12, 5)", "1"},
+	})
+}
+
+// The results are only shown to 10 significant figures, but keep their full precision so
+// they can be used in later calculations.
+func TestDistributionPrecision(t *testing.T) {
+	testEval(t, []evalTest{
+		{"NORMCD(-1, 1) * 1", "0.6826894921370859"},
+		{"NORMCD(-1, 1) -> A\nA", "0.6826894921"},
+		{"NORMCD(-1, 1) -> A\nA - 0.6826894921", "0.00000000003708588991457873"},
+		{"INVN(0.95, 2, 35, CENTER) + 0", "{31.080072030919894, 38.919927969080106}"},
+	})
+}
+
+func TestDistributionErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"NORMPD(1, 2)", "wrong number of arguments. got=2, want=1 or 3"},
+		{"NORMPD(1, 0, 0)", "ArgumentERROR: `NORMPD` needs σ > 0, got=0"},
+		{"NORMCD(2, 1)", "ArgumentERROR: `NORMCD` needs lower ≤ upper, got=2 and 1"},
+		{"INVN(1)", "ArgumentERROR: `INVN` needs 0 < area < 1, got=1"},
+		{"INVN({0.5}, CENTER)", "ArgumentERROR: `INVN` can't use lists with the CENTER tail"},
+		{"BINOMPD(1.5, 3, 0.5)", "ArgumentERROR: `BINOMPD` needs a whole number of successes, got=1.5"},
+		{"BINOMPD(1, 3, 2)", "ArgumentERROR: `BINOMPD` needs 0 ≤ p ≤ 1, got=2"},
+		{"BINOMCD(1, -3, 0.5)", "ArgumentERROR: `BINOMCD` needs a whole number of trials N ≥ 0, got=-3"},
+		{"POISSONPD(1, 0)", "ArgumentERROR: `POISSONPD` needs λ > 0, got=0"},
+		{"POISSONCD(1e12, 1e12)", "MathERROR"},
+		{"BINOMCD(1e12, 2e12, 0.5)", "MathERROR"},
+		{"NORMPD({1, 2}, {1}, 0)", "Dimension ERROR: lists given to `NORMPD` must have the same number of elements, got=2 and 1"},
+	})
+}
@@ -0,0 +1,468 @@
+package builtins
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// EQN_FIGURES is the number of significant figures the solutions found in EQN mode are
+// rounded to, so that the roots of x²-2x+1 are 1 rather than 0.9999999999999998.
+const EQN_FIGURES int = 15
+
+// ROOT_ITERATIONS is the maximum number of iterations used to find the roots of a cubic or
+// quartic.
+const ROOT_ITERATIONS int = 500
+
+// CLUSTER_TOL is how close roots found numerically have to be, relative to their size, to
+// be checked for being a single repeated root. Repeated roots are only found to around
+// the cube or fourth root of the machine precision, so this is fairly large.
+const CLUSTER_TOL float64 = 1e-3
+
+// REFINEMENT_STEPS is the number of times the solution to a linear system is improved by
+// solving for the error left over.
+const REFINEMENT_STEPS int = 3
+
+// unknowns are the names of the unknowns in a linear system, like the calculator.
+var unknowns = []string{"x", "y", "z", "t"}
+
+// BuiltinSimul solves a system of 2 to 4 linear equations, given as a square matrix of
+// coefficients and a list of constants, so that SIMUL([[1, 2], [3, 4]], {5, 6}) solves
+// x+2y=5 and 3x+4y=6.
+func BuiltinSimul(args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	m, ok := object.Value(args[0]).(*object.Matrix)
+	if !ok {
+		return newError("argument 1 to `SIMUL` not supported, got=%s", args[0].Type())
+	}
+
+	var constants []float64
+	switch val := object.Value(args[1]).(type) {
+	case *object.List:
+		constants = val.Values
+	case *object.Vector:
+		constants = val.Values
+	default:
+		return newError("argument 2 to `SIMUL` not supported, got=%s", args[1].Type())
+	}
+
+	n := m.Rows()
+	if n != m.Cols() || n < 2 {
+		return newError("Dimension ERROR: SIMUL needs a square matrix with 2 to %d unknowns, got=%s", object.MAX_MATRIX_SIZE, m.Dimension())
+	}
+
+	if len(constants) != n {
+		return newError("Dimension ERROR: SIMUL needs a constant for each of the %d equations, got=%d", n, len(constants))
+	}
+
+	det, inverse := eliminate(m)
+	if det == 0 {
+		if rank(m.Values, nil) < rank(m.Values, constants) {
+			return newError("MathERROR: no solution")
+		}
+
+		return newError("MathERROR: infinitely many solutions")
+	}
+
+	// The solution is improved by solving for the error left over, which recovers most of
+	// the precision lost when finding the inverse.
+	x := make([]float64, n)
+	for step := 0; step < REFINEMENT_STEPS; step++ {
+		residual := make([]float64, n)
+		for i, row := range m.Values {
+			residual[i] = constants[i]
+			for j, c := range row {
+				residual[i] -= c * x[j]
+			}
+		}
+
+		for i, row := range inverse.Values {
+			for j, c := range row {
+				x[i] += c * residual[j]
+			}
+		}
+	}
+
+	solution := &object.EquationSolution{}
+	for i := range x {
+		solution.Names = append(solution.Names, unknowns[i])
+		solution.Values = append(solution.Values, &object.Float{Value: object.RoundSignificant(x[i], EQN_FIGURES)})
+	}
+
+	return solution
+}
+
+// BuiltinPoly finds the roots of a polynomial of degree 2 to 4, given its coefficients
+// from the highest power down, so that POLY(1, -3, 2) solves x²-3x+2=0.
+func BuiltinPoly(args ...object.Object) object.Object {
+	if len(args) < 3 || len(args) > 5 {
+		return newError("wrong number of arguments. got=%d, want=3 to 5", len(args))
+	}
+
+	coefficients := make([]float64, len(args))
+	for i, arg := range args {
+		x, err := floatArgument("POLY", []object.Object{arg})
+		if err != nil {
+			return newError("argument %d to `POLY` not supported, got=%s", i+1, arg.Type())
+		}
+
+		coefficients[i] = x
+	}
+
+	if coefficients[0] == 0 {
+		return newError("ArgumentERROR: the leading coefficient given to `POLY` can't be 0")
+	}
+
+	solution := &object.EquationSolution{}
+	for i, z := range solvePolynomial(coefficients) {
+		// Roots too large to represent can't be shown.
+		if cmplx.IsInf(z) || cmplx.IsNaN(z) {
+			return newError("MathERROR")
+		}
+
+		solution.Names = append(solution.Names, fmt.Sprintf("x%d", i+1))
+		solution.Values = append(solution.Values, object.NewComplex(z))
+	}
+
+	return solution
+}
+
+// solvePolynomial finds the roots of a polynomial of degree 2 to 4, rounded to
+// EQN_FIGURES. Real roots come first, largest first, followed by any complex roots.
+func solvePolynomial(coefficients []float64) []complex128 {
+	var roots []complex128
+	if len(coefficients) == 3 {
+		roots = quadraticRoots(coefficients[0], coefficients[1], coefficients[2])
+	} else {
+		roots = polynomialRoots(coefficients)
+	}
+
+	for i, z := range roots {
+		if cmplx.IsInf(z) || cmplx.IsNaN(z) {
+			continue
+		}
+
+		re := object.RoundSignificant(real(z), EQN_FIGURES)
+		im := object.RoundSignificant(imag(z), EQN_FIGURES)
+
+		// Parts which are tiny compared to the root are left over from the calculation. The
+		// Aberth method also leaves tiny imaginary parts on roots at 0, which the quadratic
+		// formula doesn't, so tiny complex roots of a quadratic are kept.
+		noise := 1e-12 * cmplx.Abs(z)
+		if len(coefficients) > 3 {
+			noise = 1e-12 * math.Max(1, cmplx.Abs(z))
+		}
+
+		if math.Abs(im) <= noise {
+			im = 0
+		}
+
+		if math.Abs(re) <= 1e-12*cmplx.Abs(z) {
+			re = 0
+		}
+
+		roots[i] = complex(re, im)
+	}
+
+	sort.Slice(roots, func(i, j int) bool {
+		a, b := roots[i], roots[j]
+		if (imag(a) == 0) != (imag(b) == 0) {
+			return imag(a) == 0
+		}
+
+		if real(a) != real(b) {
+			return real(a) > real(b)
+		}
+
+		return imag(a) > imag(b)
+	})
+
+	return roots
+}
+
+// BuiltinVertex finds the turning point of the parabola y=ax²+bx+c, which is its minimum or
+// maximum, as a vector [x, y].
+func BuiltinVertex(args ...object.Object) object.Object {
+	if len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=3", len(args))
+	}
+
+	v := make([]float64, 3)
+	for i, arg := range args {
+		x, err := floatArgument("VERTEX", []object.Object{arg})
+		if err != nil {
+			return newError("argument %d to `VERTEX` not supported, got=%s", i+1, arg.Type())
+		}
+
+		v[i] = x
+	}
+
+	a, b, c := v[0], v[1], v[2]
+	if a == 0 {
+		return newError("ArgumentERROR: the leading coefficient given to `VERTEX` can't be 0")
+	}
+
+	return &object.Vector{Values: []float64{
+		object.RoundSignificant(-b/(2*a), EQN_FIGURES),
+		object.RoundSignificant(c-b*b/(4*a), EQN_FIGURES),
+	}}
+}
+
+// BuiltinSol picks out one of the solutions found by SIMUL or POLY, counting from 1, so
+// that SOL(POLY(1, -3, 2), 2) is x2.
+func BuiltinSol(args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	solution, ok := args[0].(*object.EquationSolution)
+	if !ok {
+		return newError("argument 1 to `SOL` not supported, got=%s", args[0].Type())
+	}
+
+	ints, err := integerArguments("SOL", args[1:])
+	if err != nil {
+		return err
+	}
+
+	n := ints[0].Int64()
+	if !ints[0].IsInt64() || n < 1 || n > int64(len(solution.Values)) {
+		return newError("ArgumentERROR: `SOL` needs a solution from 1 to %d, got=%s", len(solution.Values), ints[0].String())
+	}
+
+	return solution.Values[n-1]
+}
+
+// quadraticRoots finds the roots of ax²+bx+c. Real roots are found without subtracting
+// numbers that are close together, which would lose precision. The coefficients are first
+// scaled by a power of two so that the largest is close to 1, which doesn't change the
+// roots but stops b² and 4ac from overflowing.
+func quadraticRoots(a, b, c float64) []complex128 {
+	_, exponent := math.Frexp(math.Max(math.Abs(a), math.Max(math.Abs(b), math.Abs(c))))
+	a, b, c = math.Ldexp(a, -exponent), math.Ldexp(b, -exponent), math.Ldexp(c, -exponent)
+
+	discriminant := b*b - 4*a*c
+
+	if discriminant < 0 {
+		re := -b / (2 * a)
+		im := math.Sqrt(-discriminant) / (2 * math.Abs(a))
+
+		return []complex128{complex(re, im), complex(re, -im)}
+	}
+
+	// Without a linear term, the roots are symmetric, so both are found the same way.
+	if b == 0 {
+		r := math.Sqrt(discriminant) / (2 * math.Abs(a))
+		return []complex128{complex(r, 0), complex(-r, 0)}
+	}
+
+	q := -(b + math.Copysign(math.Sqrt(discriminant), b)) / 2
+
+	return []complex128{complex(q/a, 0), complex(c/q, 0)}
+}
+
+// polynomialRoots finds the roots of a polynomial using the Aberth method, which improves
+// guesses for every root at once. Roots that are found close together are checked to see
+// if they are really a single repeated root, which the method only finds approximately.
+func polynomialRoots(coefficients []float64) []complex128 {
+	degree := len(coefficients) - 1
+
+	// The polynomial is divided by its leading coefficient, and every root lies within a
+	// circle whose radius is bounded by the size of the other coefficients.
+	monic := make([]complex128, len(coefficients))
+	radius := 0.0
+
+	for i, c := range coefficients {
+		monic[i] = complex(c/coefficients[0], 0)
+		if i > 0 {
+			radius = math.Max(radius, cmplx.Abs(monic[i]))
+		}
+	}
+
+	roots := make([]complex128, degree)
+	for k := range roots {
+		roots[k] = cmplx.Rect(1+radius, 2*math.Pi*float64(k)/float64(degree)+0.4)
+	}
+
+	for iteration := 0; iteration < ROOT_ITERATIONS; iteration++ {
+		converged := true
+
+		for k, z := range roots {
+			p, dp := horner(monic, z)
+			if p == 0 {
+				continue
+			}
+
+			// A zero derivative would stop Newton's method, so the root is nudged instead.
+			ratio := p
+			if dp != 0 {
+				ratio = p / dp
+			}
+
+			repulsion := complex128(0)
+			for j, w := range roots {
+				if j != k {
+					repulsion += 1 / (z - w)
+				}
+			}
+
+			step := ratio / (1 - ratio*repulsion)
+			roots[k] = z - step
+
+			if cmplx.Abs(step) > 1e-15*math.Max(1, cmplx.Abs(z)) {
+				converged = false
+			}
+		}
+
+		if converged {
+			break
+		}
+	}
+
+	return mergeClusters(monic, roots)
+}
+
+// mergeClusters replaces roots which are close together by their average, if the
+// polynomial is close enough to zero there for them to be a single repeated root. The
+// average of the approximations to a repeated root is far more accurate than any of them.
+func mergeClusters(monic []complex128, roots []complex128) []complex128 {
+	merged := make([]bool, len(roots))
+
+	for i := range roots {
+		if merged[i] {
+			continue
+		}
+
+		cluster := []int{i}
+		for j := i + 1; j < len(roots); j++ {
+			if !merged[j] && cmplx.Abs(roots[j]-roots[i]) <= CLUSTER_TOL*math.Max(1, cmplx.Abs(roots[i])) {
+				cluster = append(cluster, j)
+			}
+		}
+
+		if len(cluster) == 1 {
+			continue
+		}
+
+		mean := complex128(0)
+		for _, j := range cluster {
+			mean += roots[j]
+		}
+		mean /= complex(float64(len(cluster)), 0)
+
+		p, _ := horner(monic, mean)
+		scale := 0.0
+		for i, c := range monic {
+			scale += cmplx.Abs(c) * math.Pow(math.Max(1, cmplx.Abs(mean)), float64(len(monic)-1-i))
+		}
+
+		if cmplx.Abs(p) > 1e-12*scale {
+			continue
+		}
+
+		mean = polishRepeatedRoot(monic, mean, len(cluster))
+
+		for _, j := range cluster {
+			roots[j] = mean
+			merged[j] = true
+		}
+	}
+
+	return roots
+}
+
+// polishRepeatedRoot improves an approximation to a root repeated k times. Such a root is
+// a simple root of the (k-1)th derivative of the polynomial, so Newton's method converges
+// quickly there.
+func polishRepeatedRoot(monic []complex128, z complex128, k int) complex128 {
+	derivative := append([]complex128{}, monic...)
+	for i := 1; i < k; i++ {
+		degree := len(derivative) - 1
+		next := make([]complex128, degree)
+
+		for j := range next {
+			next[j] = derivative[j] * complex(float64(degree-j), 0)
+		}
+
+		derivative = next
+	}
+
+	for iteration := 0; iteration < ROOT_ITERATIONS; iteration++ {
+		q, dq := horner(derivative, z)
+		if dq == 0 {
+			break
+		}
+
+		step := q / dq
+		z -= step
+
+		if cmplx.Abs(step) <= 1e-16*math.Max(1, cmplx.Abs(z)) {
+			break
+		}
+	}
+
+	return z
+}
+
+// horner evaluates a polynomial and its derivative at a point.
+func horner(coefficients []complex128, z complex128) (complex128, complex128) {
+	p, dp := complex128(0), complex128(0)
+
+	for _, c := range coefficients {
+		dp = dp*z + p
+		p = p*z + c
+	}
+
+	return p, dp
+}
+
+// rank finds the rank of a matrix, optionally with an extra column on the right, using
+// Gaussian elimination.
+func rank(values [][]float64, extra []float64) int {
+	rows := make([][]float64, len(values))
+	scale := 0.0
+
+	for i, row := range values {
+		rows[i] = append([]float64{}, row...)
+		if extra != nil {
+			rows[i] = append(rows[i], extra[i])
+		}
+
+		for _, x := range rows[i] {
+			scale = math.Max(scale, math.Abs(x))
+		}
+	}
+
+	r := 0
+	for col := 0; col < len(rows[0]) && r < len(rows); col++ {
+		pivot := r
+		for row := r + 1; row < len(rows); row++ {
+			if math.Abs(rows[row][col]) > math.Abs(rows[pivot][col]) {
+				pivot = row
+			}
+		}
+
+		if math.Abs(rows[pivot][col]) <= SINGULAR_TOL*scale {
+			continue
+		}
+
+		rows[pivot], rows[r] = rows[r], rows[pivot]
+
+		for row := r + 1; row < len(rows); row++ {
+			factor := rows[row][col] / rows[r][col]
+			for j := col; j < len(rows[row]); j++ {
+				rows[row][j] -= factor * rows[r][j]
+			}
+		}
+
+		r++
+	}
+
+	return r
+}
@@ -0,0 +1,46 @@
+package builtins_test
+
+import "testing"
+
+func TestEquations(t *testing.T) {
+	testEval(t, []evalTest{
+		{"SIMUL([[1, 2], [3, 4]], {5, 6})", "x=-4, y=4.5"},
+		{"SIMUL([[2, 1, -1], [-3, -1, 2], [-2, 1, 2]], {8, -11, -3})", "x=2, y=3, z=-1"},
+		{"SOL(SIMUL([[1, 2], [3, 4]], {5, 6}), 2)", "4.5"},
+
+		{"POLY(1, -3, 2)", "x1=2, x2=1"},
+		{"POLY(1, -2, 1)", "x1=1, x2=1"},
+		{"POLY(1, 2, 5)", "x1=-1+2i, x2=-1-2i"},
+		{"SOL(POLY(1, 1e160, 1), 1) * 1e160", "-1"},
+		{"SOL(POLY(1, 1e160, 1), 2) / 1e160", "-1"},
+		{"POLY(1e200, 3e200, 2e200)", "x1=-1, x2=-2"},
+		{"POLY(1e-200, 3e-200, 2e-200)", "x1=-1, x2=-2"},
+		{"POLY(1e200, 1, 1e200)", "x1=i, x2=-i"},
+		{"SOL(POLY(1, 1e-200, 1e-200), 1) * 1e100", "i"},
+		{"POLY(1, -6, 11, -6)", "x1=3, x2=2, x3=1"},
+		{"POLY(1, -3, 3, -1)", "x1=1, x2=1, x3=1"},
+		{"POLY(1, 0, 0, -1)", "x1=1, x2=-0.5+0.866025403784439i, x3=-0.5-0.866025403784439i"},
+		{"POLY(1, -10, 35, -50, 24)", "x1=4, x2=3, x3=2, x4=1"},
+		{"POLY(1, 0, 0, 0, -16)", "x1=2, x2=-2, x3=2i, x4=-2i"},
+		{"POLY(1, -4, 6, -4, 1)", "x1=1, x2=1, x3=1, x4=1"},
+		{"POLY(1, 0, 2, 0, 1)", "x1=i, x2=i, x3=-i, x4=-i"},
+		{"SOL(POLY(1, 2, 5), 1)", "-1+2i"},
+
+		{"VERTEX(1, -3, 2)", "[1.5, -0.25]"},
+		{"VERTEX(-2, 4, 1)", "[1, 3]"},
+	})
+}
+
+func TestEquationErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"SIMUL([[1, 2], [2, 4]], {1, 2})", "MathERROR: infinitely many solutions"},
+		{"SIMUL([[1, 2], [2, 4]], {1, 3})", "MathERROR: no solution"},
+		{"SIMUL([[1, 2], [3, 4]], {1})", "Dimension ERROR: SIMUL needs a constant for each of the 2 equations, got=1"},
+		{"POLY(0, 1, 2)", "ArgumentERROR: the leading coefficient given to `POLY` can't be 0"},
+		{"POLY(1, 2)", "wrong number of arguments. got=2, want=3 to 5"},
+		{"POLY(1e-300, 1e300, 1)", "MathERROR"},
+		{"POLY(1e-300, 1e300, 1, 1)", "MathERROR"},
+		{"SOL(POLY(1, -3, 2), 3)", "ArgumentERROR: `SOL` needs a solution from 1 to 2, got=3"},
+		{"VERTEX(0, 1, 2)", "ArgumentERROR: the leading coefficient given to `VERTEX` can't be 0"},
+	})
+}
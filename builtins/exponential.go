@@ -0,0 +1,139 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// BuiltinLn finds the natural logarithm of a number.
+func BuiltinLn(args ...object.Object) object.Object {
+	x, err := floatArgument("LN", args)
+	if err != nil {
+		return err
+	}
+
+	if x <= 0 {
+		return newError("MathERROR")
+	}
+
+	return floatResult(math.Log(x))
+}
+
+// BuiltinLog finds the logarithm of a number. With one argument it is the base 10
+// logarithm; with two arguments it is LOG(base, x), like logₐb on the calculator.
+func BuiltinLog(args ...object.Object) object.Object {
+	if len(args) == 1 {
+		x, err := floatArgument("LOG", args)
+		if err != nil {
+			return err
+		}
+
+		if x <= 0 {
+			return newError("MathERROR")
+		}
+
+		return floatResult(math.Log10(x))
+	}
+
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=1 or 2", len(args))
+	}
+
+	base, err := floatArgument("LOG", args[:1])
+	if err != nil {
+		return err
+	}
+
+	x, err := floatArgument("LOG", args[1:])
+	if err != nil {
+		return err
+	}
+
+	if base <= 0 || base == 1 || x <= 0 {
+		return newError("MathERROR")
+	}
+
+	return floatResult(math.Log(x) / math.Log(base))
+}
+
+// BuiltinExp raises e to the power of a number.
+func BuiltinExp(args ...object.Object) object.Object {
+	x, err := floatArgument("EXP", args)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(math.Exp(x))
+}
+
+// BuiltinExp10 raises 10 to the power of a number, like the 10^x key on the calculator.
+func BuiltinExp10(args ...object.Object) object.Object {
+	x, err := floatArgument("EXP10", args)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(math.Pow(10, x))
+}
+
+// BuiltinSinh finds the hyperbolic sine of a number.
+func BuiltinSinh(args ...object.Object) object.Object {
+	x, err := floatArgument("SINH", args)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(math.Sinh(x))
+}
+
+// BuiltinCosh finds the hyperbolic cosine of a number.
+func BuiltinCosh(args ...object.Object) object.Object {
+	x, err := floatArgument("COSH", args)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(math.Cosh(x))
+}
+
+// BuiltinTanh finds the hyperbolic tangent of a number.
+func BuiltinTanh(args ...object.Object) object.Object {
+	x, err := floatArgument("TANH", args)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(math.Tanh(x))
+}
+
+// BuiltinAsinh finds the inverse hyperbolic sine of a number.
+func BuiltinAsinh(args ...object.Object) object.Object {
+	x, err := floatArgument("ASINH", args)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(math.Asinh(x))
+}
+
+// BuiltinAcosh finds the inverse hyperbolic cosine of a number, which must be at least 1.
+func BuiltinAcosh(args ...object.Object) object.Object {
+	x, err := floatArgument("ACOSH", args)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(math.Acosh(x))
+}
+
+// BuiltinAtanh finds the inverse hyperbolic tangent of a number, which must be strictly
+// between -1 and 1.
+func BuiltinAtanh(args ...object.Object) object.Object {
+	x, err := floatArgument("ATANH", args)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(math.Atanh(x))
+}
@@ -0,0 +1,46 @@
+package builtins_test
+
+import "testing"
+
+func TestExponentials(t *testing.T) {
+	testEval(t, []evalTest{
+		{"LN(1)", "0"},
+		{"LN(e)", "1"},
+		{"LOG(1000)", "3"},
+		{"LOG(0.01)", "-2"},
+		{"LOG(2, 8)", "3"},
+		{"EXP(0)", "1"},
+		{"EXP(1)", "2.718281828459045"},
+		{"EXP10(3)", "1000"},
+		{"EXP10(-2)", "0.01"},
+		{"ABS(-3)", "3"},
+		{"ABS(2.5)", "2.5"},
+
+		{"SINH(0)", "0"},
+		{"SINH(1)", "1.1752011936438014"},
+		{"COSH(0)", "1"},
+		{"COSH(1)", "1.5430806348152437"},
+		{"TANH(1)", "0.7615941559557649"},
+		{"ASINH(1)", "0.881373587019543"},
+		{"ACOSH(1)", "0"},
+		{"ACOSH(2)", "1.3169578969248166"},
+		{"ATANH(0.5)", "0.5493061443340548"},
+	})
+}
+
+func TestExponentialErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"LN(0)", "MathERROR"},
+		{"LN(-1)", "MathERROR"},
+		{"LOG(-10)", "MathERROR"},
+		{"LOG(1, 5)", "MathERROR"},
+		{"LOG(2, 0)", "MathERROR"},
+		{"EXP(1000)", "MathERROR"},
+		{"EXP10(400)", "MathERROR"},
+		{"SINH(1000)", "MathERROR"},
+		{"ACOSH(0.5)", "MathERROR"},
+		{"ATANH(1)", "MathERROR"},
+		{"ATANH(-1)", "MathERROR"},
+		{"LN(1, 2)", "wrong number of arguments. got=2, want=1"},
+	})
+}
@@ -0,0 +1,84 @@
+package builtins
+
+import (
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// variableArgument checks that an argument to a special form is a variable, such as the
+// second X in Σ(X, X, 1, 10).
+func variableArgument(name string, i int, arg ast.Expression) (*ast.Identifier, *object.Error) {
+	ident, ok := arg.(*ast.Identifier)
+	if !ok {
+		return nil, newError("ArgumentERROR: argument %d to `%s` must be a variable, got=%s", i+1, name, arg.String())
+	}
+
+	return ident, nil
+}
+
+// evalFloatArgument evaluates an argument to a special form, and converts it to a float.
+func evalFloatArgument(in object.Interpreter, env *object.Environment, name string, i int, arg ast.Expression) (float64, *object.Error) {
+	val := in.Eval(arg, env)
+	if isError(val) {
+		return 0, val.(*object.Error)
+	}
+
+	switch val := object.Value(val).(type) {
+	case *object.Float:
+		return val.Value, nil
+	case *object.Integer:
+		return float64(val.Value), nil
+	case *object.BigInteger:
+		return object.BigIntegerToFloat(val).Value, nil
+	default:
+		return 0, newError("argument %d to `%s` not supported, got=%s", i+1, name, val.Type())
+	}
+}
+
+// evalIntegerArgument evaluates an argument to a special form, and converts it to an
+// integer. Floats are accepted as long as they are whole numbers.
+func evalIntegerArgument(in object.Interpreter, env *object.Environment, name string, i int, arg ast.Expression) (int64, *object.Error) {
+	val := in.Eval(arg, env)
+	if isError(val) {
+		return 0, val.(*object.Error)
+	}
+
+	ints, err := integerArguments(name, []object.Object{val})
+	if err != nil {
+		return 0, newError("ArgumentERROR: argument %d to `%s` must be an integer, got=%s", i+1, name, val.Inspect())
+	}
+
+	if !ints[0].IsInt64() {
+		return 0, newError("MathERROR")
+	}
+
+	return ints[0].Int64(), nil
+}
+
+// function turns an expression in a variable into a function of that variable, such as
+// X*X into x ↦ x², by repeatedly evaluating it in an enclosed environment.
+func function(in object.Interpreter, env *object.Environment, name string, body ast.Expression, variable *ast.Identifier) func(float64) (float64, *object.Error) {
+	inner := object.NewEnclosedEnvironment(env)
+
+	return func(x float64) (float64, *object.Error) {
+		if set := inner.Set(variable.Value, &object.Float{Value: x}); isError(set) {
+			return 0, set.(*object.Error)
+		}
+
+		val := in.Eval(body, inner)
+		if isError(val) {
+			return 0, val.(*object.Error)
+		}
+
+		switch val := object.Value(val).(type) {
+		case *object.Float:
+			return val.Value, nil
+		case *object.Integer:
+			return float64(val.Value), nil
+		case *object.BigInteger:
+			return object.BigIntegerToFloat(val).Value, nil
+		default:
+			return 0, newError("expression given to `%s` must be a number, got=%s", name, val.Type())
+		}
+	}
+}
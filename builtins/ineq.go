@@ -0,0 +1,182 @@
+package builtins
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// Relation is the comparison in an inequality solved in INEQ mode, such as the > in
+// ax²+bx+c>0.
+type Relation string
+
+// Definition of relations.
+const (
+	RELATION_GT Relation = ">"
+	RELATION_GE Relation = "≥"
+	RELATION_LT Relation = "<"
+	RELATION_LE Relation = "≤"
+)
+
+// ParseRelation parses the name of a relation, either as a symbol such as >= or ≥, or as
+// a name such as GT.
+func ParseRelation(name string) (Relation, bool) {
+	switch strings.ToUpper(name) {
+	case ">", "GT":
+		return RELATION_GT, true
+	case ">=", "≥", "GE":
+		return RELATION_GE, true
+	case "<", "LT":
+		return RELATION_LT, true
+	case "<=", "≤", "LE":
+		return RELATION_LE, true
+	default:
+		return "", false
+	}
+}
+
+// BuiltinIneq solves a polynomial inequality of degree 2 to 4, given its coefficients from
+// the highest power down followed by GT, GE, LT or LE, so that INEQ(1, -2, -3, GT) solves
+// x²-2x-3>0.
+func BuiltinIneq(in object.Interpreter, env *object.Environment, args ...ast.Expression) object.Object {
+	if len(args) < 4 || len(args) > 6 {
+		return newError("wrong number of arguments. got=%d, want=4 to 6", len(args))
+	}
+
+	last := args[len(args)-1]
+
+	ident, ok := last.(*ast.Identifier)
+	if !ok {
+		return newError("ArgumentERROR: the last argument to `INEQ` must be GT, GE, LT or LE, got=%s", last.String())
+	}
+
+	relation, ok := ParseRelation(ident.Value)
+	if !ok {
+		return newError("ArgumentERROR: the last argument to `INEQ` must be GT, GE, LT or LE, got=%s", ident.Value)
+	}
+
+	coefficients := make([]float64, len(args)-1)
+	for i, arg := range args[:len(args)-1] {
+		x, err := evalFloatArgument(in, env, "INEQ", i, arg)
+		if err != nil {
+			return err
+		}
+
+		coefficients[i] = x
+	}
+
+	return SolveInequality(coefficients, relation)
+}
+
+// SolveInequality solves a polynomial inequality of degree 2 to 4, comparing the
+// polynomial with 0. The real roots split the number line into intervals where the
+// polynomial doesn't change sign, so each one can be checked at a single point.
+func SolveInequality(coefficients []float64, relation Relation) object.Object {
+	if len(coefficients) < 3 || len(coefficients) > 5 {
+		return newError("ArgumentERROR: `INEQ` needs a polynomial of degree 2 to 4, got=%d coefficients", len(coefficients))
+	}
+
+	if coefficients[0] == 0 {
+		return newError("ArgumentERROR: the leading coefficient given to `INEQ` can't be 0")
+	}
+
+	roots := []float64{}
+	for _, z := range solvePolynomial(coefficients) {
+		if imag(z) == 0 {
+			roots = append(roots, real(z))
+		}
+	}
+
+	// Real roots come out largest first, so reversing them puts them in increasing order
+	// with any repeated roots next to each other.
+	distinct := []float64{}
+	for i := len(roots) - 1; i >= 0; i-- {
+		if len(distinct) == 0 || distinct[len(distinct)-1] != roots[i] {
+			distinct = append(distinct, roots[i])
+		}
+	}
+
+	satisfies := func(x float64) bool {
+		value := 0.0
+		for _, c := range coefficients {
+			value = value*x + c
+		}
+
+		switch relation {
+		case RELATION_GT:
+			return value > 0
+		case RELATION_GE:
+			return value >= 0
+		case RELATION_LT:
+			return value < 0
+		default:
+			return value <= 0
+		}
+	}
+
+	set := &object.IntervalSet{}
+	var current *object.Interval
+
+	flush := func() {
+		if current != nil {
+			set.Intervals = append(set.Intervals, *current)
+			current = nil
+		}
+	}
+
+	for k := 0; k <= len(distinct); k++ {
+		lower, upper := math.Inf(-1), math.Inf(1)
+		if k > 0 {
+			lower = distinct[k-1]
+		}
+		if k < len(distinct) {
+			upper = distinct[k]
+		}
+
+		if satisfies(samplePoint(lower, upper)) {
+			if current == nil {
+				current = &object.Interval{Lower: lower}
+			}
+
+			current.Upper, current.UpperClosed = upper, false
+		} else {
+			flush()
+		}
+
+		if k == len(distinct) {
+			break
+		}
+
+		// The polynomial is zero at a root, so roots are only included for ≥ and ≤.
+		if relation == RELATION_GE || relation == RELATION_LE {
+			if current == nil {
+				current = &object.Interval{Lower: upper, LowerClosed: true}
+			}
+
+			current.Upper, current.UpperClosed = upper, true
+		} else {
+			flush()
+		}
+	}
+
+	flush()
+
+	return set
+}
+
+// samplePoint picks a point strictly inside an interval, which may be infinite at either
+// end.
+func samplePoint(lower, upper float64) float64 {
+	switch {
+	case math.IsInf(lower, -1) && math.IsInf(upper, 1):
+		return 0
+	case math.IsInf(lower, -1):
+		return upper - math.Max(1, math.Abs(upper))
+	case math.IsInf(upper, 1):
+		return lower + math.Max(1, math.Abs(lower))
+	default:
+		return lower + (upper-lower)/2
+	}
+}
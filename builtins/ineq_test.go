@@ -0,0 +1,33 @@
+package builtins_test
+
+import "testing"
+
+func TestInequalities(t *testing.T) {
+	testEval(t, []evalTest{
+		{"INEQ(1, -2, -3, GT)", "x<-1, 3<x"},
+		{"INEQ(1, -2, -3, GE)", "x≤-1, 3≤x"},
+		{"INEQ(1, -2, -3, LT)", "-1<x<3"},
+		{"INEQ(1, -2, -3, LE)", "-1≤x≤3"},
+		{"INEQ(-1, 2, 3, GT)", "-1<x<3"},
+
+		{"INEQ(1, -2, 1, GT)", "x≠1"},
+		{"INEQ(1, -2, 1, GE)", "All Real Numbers"},
+		{"INEQ(1, -2, 1, LT)", "No Solution"},
+		{"INEQ(1, -2, 1, LE)", "x=1"},
+		{"INEQ(1, 2, 5, GT)", "All Real Numbers"},
+		{"INEQ(1, 2, 5, LE)", "No Solution"},
+
+		{"INEQ(1, -6, 11, -6, LE)", "x≤1, 2≤x≤3"},
+		{"INEQ(1, -10, 35, -50, 24, GT)", "x<1, 2<x<3, 4<x"},
+		{"INEQ(1, 0, -1, ≥)", "x≤-1, 1≤x"},
+	})
+}
+
+func TestInequalityErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"INEQ(0, 1, 2, GT)", "ArgumentERROR: the leading coefficient given to `INEQ` can't be 0"},
+		{"INEQ(1, 2, GT)", "wrong number of arguments. got=3, want=4 to 6"},
+		{"INEQ(1, 2, 3, EQ)", "ArgumentERROR: the last argument to `INEQ` must be GT, GE, LT or LE, got=EQ"},
+		{"INEQ(1, 2, 3, 4)", "ArgumentERROR: the last argument to `INEQ` must be GT, GE, LT or LE, got=4"},
+	})
+}
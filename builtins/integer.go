@@ -0,0 +1,197 @@
+package builtins
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// MAX_PERMUTATION_TERMS is the largest number of terms NPR and NCR will multiply
+// together before giving up with a MathERROR, so that huge arguments don't hang the
+// program.
+const MAX_PERMUTATION_TERMS int64 = 100000
+
+// BuiltinGcd finds the greatest common divisor of two or more integers.
+func BuiltinGcd(args ...object.Object) object.Object {
+	if len(args) < 2 {
+		return newError("wrong number of arguments. got=%d, want:>=2", len(args))
+	}
+
+	ints, err := integerArguments("GCD", args)
+	if err != nil {
+		return err
+	}
+
+	result := new(big.Int).Abs(ints[0])
+	for _, n := range ints[1:] {
+		result.GCD(nil, nil, result, new(big.Int).Abs(n))
+	}
+
+	return object.NewInteger(result)
+}
+
+// BuiltinLcm finds the lowest common multiple of two or more integers.
+func BuiltinLcm(args ...object.Object) object.Object {
+	if len(args) < 2 {
+		return newError("wrong number of arguments. got=%d, want:>=2", len(args))
+	}
+
+	ints, err := integerArguments("LCM", args)
+	if err != nil {
+		return err
+	}
+
+	result := new(big.Int).Abs(ints[0])
+	for _, n := range ints[1:] {
+		n = new(big.Int).Abs(n)
+
+		if result.Sign() == 0 || n.Sign() == 0 {
+			result.SetInt64(0)
+			continue
+		}
+
+		gcd := new(big.Int).GCD(nil, nil, result, n)
+		result.Mul(result, n).Quo(result, gcd)
+	}
+
+	return object.NewInteger(result)
+}
+
+// BuiltinNpr finds the number of permutations of r items chosen from n, n!/(n-r)!.
+func BuiltinNpr(args ...object.Object) object.Object {
+	n, r, err := permutationArguments("NPR", args)
+	if err != nil {
+		return err
+	}
+
+	if r > MAX_PERMUTATION_TERMS {
+		return newError("MathERROR")
+	}
+
+	return object.NewInteger(new(big.Int).MulRange(n-r+1, n))
+}
+
+// BuiltinNcr finds the number of combinations of r items chosen from n, n!/(r!(n-r)!).
+func BuiltinNcr(args ...object.Object) object.Object {
+	n, r, err := permutationArguments("NCR", args)
+	if err != nil {
+		return err
+	}
+
+	if r > n-r {
+		r = n - r
+	}
+
+	if r > MAX_PERMUTATION_TERMS {
+		return newError("MathERROR")
+	}
+
+	return object.NewInteger(new(big.Int).Binomial(n, r))
+}
+
+// BuiltinInt finds the integer part of a number by truncating it towards zero, so
+// INT(-2.5) is -2.
+func BuiltinInt(args ...object.Object) object.Object {
+	return roundToInteger("INT", math.Trunc, args)
+}
+
+// BuiltinIntg finds the largest integer not greater than a number, so INTG(-2.5) is -3.
+func BuiltinIntg(args ...object.Object) object.Object {
+	return roundToInteger("INTG", math.Floor, args)
+}
+
+// BuiltinMod finds the remainder when dividing one integer by another. The result always
+// has the same sign as the divisor, so MOD(-7, 3) is 2.
+func BuiltinMod(args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	ints, err := integerArguments("MOD", args)
+	if err != nil {
+		return err
+	}
+
+	if ints[1].Sign() == 0 {
+		return newError("division error: division by zero")
+	}
+
+	result := new(big.Int).Rem(ints[0], ints[1])
+	if result.Sign() != 0 && result.Sign() != ints[1].Sign() {
+		result.Add(result, ints[1])
+	}
+
+	return object.NewInteger(result)
+}
+
+// integerArguments converts the arguments to a builtin into big integers. Floats are
+// accepted as long as they are whole numbers.
+func integerArguments(name string, args []object.Object) ([]*big.Int, *object.Error) {
+	ints := make([]*big.Int, len(args))
+
+	for i, arg := range args {
+		switch arg := object.Value(arg).(type) {
+		case *object.Integer:
+			ints[i] = big.NewInt(arg.Value)
+		case *object.BigInteger:
+			ints[i] = arg.Value
+		case *object.Float:
+			if arg.Value != math.Trunc(arg.Value) || math.IsInf(arg.Value, 0) {
+				return nil, newError("ArgumentERROR: argument %d to `%s` must be an integer, got=%s", i+1, name, arg.Inspect())
+			}
+
+			ints[i], _ = big.NewFloat(arg.Value).Int(nil)
+		default:
+			return nil, newError("argument %d to `%s` not supported, got=%s", i+1, name, arg.Type())
+		}
+	}
+
+	return ints, nil
+}
+
+// permutationArguments checks the arguments to NPR or NCR, which must be integers where
+// 0 <= r <= n.
+func permutationArguments(name string, args []object.Object) (int64, int64, *object.Error) {
+	if len(args) != 2 {
+		return 0, 0, newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	ints, err := integerArguments(name, args)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !ints[0].IsInt64() || !ints[1].IsInt64() {
+		return 0, 0, newError("MathERROR")
+	}
+
+	n, r := ints[0].Int64(), ints[1].Int64()
+	if r < 0 || n < r {
+		return 0, 0, newError("MathERROR")
+	}
+
+	return n, r, nil
+}
+
+// roundToInteger rounds a number to an integer using the given rounding function, such
+// as math.Floor.
+func roundToInteger(name string, round func(float64) float64, args []object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	switch val := object.Value(args[0]).(type) {
+	case *object.Integer, *object.BigInteger:
+		return val
+	case *object.Float:
+		if math.IsInf(val.Value, 0) || math.IsNaN(val.Value) {
+			return newError("MathERROR")
+		}
+
+		rounded, _ := big.NewFloat(round(val.Value)).Int(nil)
+		return object.NewInteger(rounded)
+	default:
+		return newError("argument to `%s` not supported, got=%s", name, args[0].Type())
+	}
+}
@@ -0,0 +1,60 @@
+package builtins_test
+
+import "testing"
+
+func TestIntegerFunctions(t *testing.T) {
+	testEval(t, []evalTest{
+		{"GCD(12, 18)", "6"},
+		{"GCD(12, 18, 8)", "2"},
+		{"GCD(-12, 18)", "6"},
+		{"GCD(0, 5)", "5"},
+		{"LCM(4, 6)", "12"},
+		{"LCM(4, 6, 10)", "60"},
+		{"LCM(0, 5)", "0"},
+		{"LCM(12T, 7)", "84000000000000"},
+
+		{"NPR(5, 2)", "20"},
+		{"NPR(5, 0)", "1"},
+		{"NCR(5, 2)", "10"},
+		{"NCR(5, 5)", "1"},
+		{"NCR(100, 50)", "100891344545564193334812497256"},
+
+		{"INT(2.5)", "2"},
+		{"INT(-2.5)", "-2"},
+		{"INTG(2.5)", "2"},
+		{"INTG(-2.5)", "-3"},
+		{"INT(7)", "7"},
+		{"INTG(1e20)", "100000000000000000000"},
+
+		{"MOD(7, 3)", "1"},
+		{"MOD(-7, 3)", "2"},
+		{"MOD(7, -3)", "-2"},
+		{"MOD(6, 3)", "0"},
+		{"MOD(NCR(100, 50), 7)", "4"},
+
+		{"17 ÷R 5", "Q=3, R=2"},
+		{"15 ÷R 5", "Q=3, R=0"},
+		{"0 ÷R 5", "Q=0, R=0"},
+		{"(17 ÷R 5) + 1", "4"},
+		{"NCR(100, 50) ÷R 1000", "Q=100891344545564193334812497, R=256"},
+	})
+}
+
+func TestIntegerFunctionErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"GCD(4)", "wrong number of arguments. got=1, want:>=2"},
+		{"GCD(4, 2.5)", "ArgumentERROR: argument 2 to `GCD` must be an integer, got=2.5"},
+		{"LCM([1, 2], 3)", "argument 1 to `LCM` not supported, got=VECTOR"},
+		{"NPR(2, 5)", "MathERROR"},
+		{"NCR(5, -1)", "MathERROR"},
+		{"NPR(1000000, 1000000)", "MathERROR"},
+		{"INT([1, 2])", "argument to `INT` not supported, got=VECTOR"},
+		{"MOD(7, 0)", "division error: division by zero"},
+		{"MOD(7.5, 2)", "ArgumentERROR: argument 1 to `MOD` must be an integer, got=7.5"},
+
+		{"7 ÷R 0", "division error: division by zero"},
+		{"-7 ÷R 2", "MathERROR"},
+		{"7 ÷R -2", "MathERROR"},
+		{"7.5 ÷R 2", "ArgumentERROR: ÷R is only defined for integers, got=FLOAT ÷R FLOAT"},
+	})
+}
@@ -0,0 +1,178 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// MATRIX_FIGURES is the number of significant figures the results of DET and INV are
+// rounded to, so that DET([[1, 2], [3, 4]]) is -2 rather than -2.0000000000000004.
+const MATRIX_FIGURES int = 15
+
+// SINGULAR_TOL is how small a pivot has to be, relative to the largest element of the
+// matrix, for the matrix to be treated as singular.
+const SINGULAR_TOL float64 = 1e-12
+
+// BuiltinDet finds the determinant of a square matrix.
+func BuiltinDet(args ...object.Object) object.Object {
+	m, err := matrixArgument("DET", args)
+	if err != nil {
+		return err
+	}
+
+	if m.Rows() != m.Cols() {
+		return newError("Dimension ERROR: DET needs a square matrix, got=%s", m.Dimension())
+	}
+
+	det, _ := eliminate(m)
+
+	return floatResult(object.RoundSignificant(det, MATRIX_FIGURES))
+}
+
+// BuiltinTrn finds the transpose of a matrix, swapping its rows and columns.
+func BuiltinTrn(args ...object.Object) object.Object {
+	m, err := matrixArgument("TRN", args)
+	if err != nil {
+		return err
+	}
+
+	t := object.NewMatrix(m.Cols(), m.Rows())
+
+	for i, row := range m.Values {
+		for j, x := range row {
+			t.Values[j][i] = x
+		}
+	}
+
+	return t
+}
+
+// BuiltinInv finds the inverse of a square matrix. Singular matrices, which have no
+// inverse, give a MathERROR.
+func BuiltinInv(args ...object.Object) object.Object {
+	m, err := matrixArgument("INV", args)
+	if err != nil {
+		return err
+	}
+
+	if m.Rows() != m.Cols() {
+		return newError("Dimension ERROR: INV needs a square matrix, got=%s", m.Dimension())
+	}
+
+	det, inverse := eliminate(m)
+	if det == 0 {
+		return newError("MathERROR")
+	}
+
+	for _, row := range inverse.Values {
+		for j := range row {
+			row[j] = object.RoundSignificant(row[j], MATRIX_FIGURES)
+		}
+	}
+
+	return inverse
+}
+
+// BuiltinIdentity creates an identity matrix with the given number of rows and columns.
+func BuiltinIdentity(args ...object.Object) object.Object {
+	ints, err := integerArguments("IDENTITY", args)
+	if err != nil {
+		return err
+	}
+
+	if len(ints) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(ints))
+	}
+
+	n := ints[0].Int64()
+	if !ints[0].IsInt64() || n < 1 || n > int64(object.MAX_MATRIX_SIZE) {
+		return newError("Dimension ERROR: IDENTITY needs a size from 1 to %d, got=%s", object.MAX_MATRIX_SIZE, ints[0].String())
+	}
+
+	m := object.NewMatrix(int(n), int(n))
+	for i := range m.Values {
+		m.Values[i][i] = 1
+	}
+
+	return m
+}
+
+// eliminate performs Gauss-Jordan elimination with partial pivoting on a square matrix,
+// returning its determinant and its inverse. The inverse is only meaningful if the
+// determinant isn't zero.
+func eliminate(m *object.Matrix) (float64, *object.Matrix) {
+	n := m.Rows()
+
+	a := object.NewMatrix(n, n)
+	inverse := object.NewMatrix(n, n)
+
+	for i := range m.Values {
+		copy(a.Values[i], m.Values[i])
+		inverse.Values[i][i] = 1
+	}
+
+	scale := 0.0
+	for _, row := range m.Values {
+		for _, x := range row {
+			scale = math.Max(scale, math.Abs(x))
+		}
+	}
+
+	det := 1.0
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a.Values[row][col]) > math.Abs(a.Values[pivot][col]) {
+				pivot = row
+			}
+		}
+
+		if math.Abs(a.Values[pivot][col]) <= SINGULAR_TOL*scale {
+			return 0, inverse
+		}
+
+		if pivot != col {
+			a.Values[pivot], a.Values[col] = a.Values[col], a.Values[pivot]
+			inverse.Values[pivot], inverse.Values[col] = inverse.Values[col], inverse.Values[pivot]
+			det = -det
+		}
+
+		p := a.Values[col][col]
+		det *= p
+
+		for j := 0; j < n; j++ {
+			a.Values[col][j] /= p
+			inverse.Values[col][j] /= p
+		}
+
+		for row := 0; row < n; row++ {
+			if row == col {
+				continue
+			}
+
+			factor := a.Values[row][col]
+			for j := 0; j < n; j++ {
+				a.Values[row][j] -= factor * a.Values[col][j]
+				inverse.Values[row][j] -= factor * inverse.Values[col][j]
+			}
+		}
+	}
+
+	return det, inverse
+}
+
+// matrixArgument checks that a builtin has been given a single matrix.
+func matrixArgument(name string, args []object.Object) (*object.Matrix, *object.Error) {
+	if len(args) != 1 {
+		return nil, newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	m, ok := object.Value(args[0]).(*object.Matrix)
+	if !ok {
+		return nil, newError("argument to `%s` not supported, got=%s", name, args[0].Type())
+	}
+
+	return m, nil
+}
@@ -2,6 +2,8 @@ package builtins
 
 import (
 	"math"
+	"math/big"
+	"math/cmplx"
 	"math/rand"
 
 	"github.com/ollybritton/calclang/object"
@@ -15,12 +17,12 @@ func BuiltinRandomInt(args ...object.Object) object.Object {
 		return newError("wrong number of arguments. got=%d, want=2", len(args))
 	}
 
-	lower, ok := args[0].(*object.Integer)
+	lower, ok := object.Value(args[0]).(*object.Integer)
 	if !ok {
 		return newError("argument 1 to `RANDOM_INT` not supported, got=%s", args[0].Type())
 	}
 
-	upper, ok := args[1].(*object.Integer)
+	upper, ok := object.Value(args[1]).(*object.Integer)
 	if !ok {
 		return newError("argument 2 to `RANDOM_INT` not supported, got=%s", args[1].Type())
 	}
@@ -29,71 +31,80 @@ func BuiltinRandomInt(args ...object.Object) object.Object {
 	return &object.Integer{Value: int64(val)}
 }
 
-// BuiltinFloor will floor a float. It has no effect on integers.
+// BuiltinFloor will floor a float. It has no effect on integers or big integers.
 func BuiltinFloor(args ...object.Object) object.Object {
 	if len(args) != 1 {
 		return newError("wrong number of arguments. got=%d, want=1", len(args))
 	}
 
-	switch val := args[0].(type) {
+	switch val := object.Value(args[0]).(type) {
 	case *object.Float:
-		return &object.Integer{Value: int64(math.Floor(val.Value))}
-	case *object.Integer:
+		return integerResult(math.Floor(val.Value))
+	case *object.Integer, *object.BigInteger:
 		return val
 	default:
 		return newError("argument to `FLOOR` not supported, got=%s", args[0].Type())
 	}
 }
 
-// BuiltinRound will round a float. It has no effect on integers.
+// BuiltinRound will round a float. It has no effect on integers or big integers.
 func BuiltinRound(args ...object.Object) object.Object {
 	if len(args) != 1 {
 		return newError("wrong number of arguments. got=%d, want=1", len(args))
 	}
 
-	switch val := args[0].(type) {
+	switch val := object.Value(args[0]).(type) {
 	case *object.Float:
-		return &object.Integer{Value: int64(math.Round(val.Value))}
-	case *object.Integer:
+		return integerResult(math.Round(val.Value))
+	case *object.Integer, *object.BigInteger:
 		return val
 	default:
 		return newError("argument to `ROUND` not supported, got=%s", args[0].Type())
 	}
 }
 
-// BuiltinCeil will round a float up. It has no effect on integers.
+// BuiltinCeil will round a float up. It has no effect on integers or big integers.
 func BuiltinCeil(args ...object.Object) object.Object {
 	if len(args) != 1 {
 		return newError("wrong number of arguments. got=%d, want=1", len(args))
 	}
 
-	switch val := args[0].(type) {
+	switch val := object.Value(args[0]).(type) {
 	case *object.Float:
-		return &object.Integer{Value: int64(math.Ceil(val.Value))}
-	case *object.Integer:
+		return integerResult(math.Ceil(val.Value))
+	case *object.Integer, *object.BigInteger:
 		return val
 	default:
 		return newError("argument to `CEIL` not supported, got=%s", args[0].Type())
 	}
 }
 
-// BuiltinSqrt will find the square root of an integer or a float.
-func BuiltinSqrt(args ...object.Object) object.Object {
+// BuiltinSqrt will find the square root of an integer, a big integer or a float. In CMPLX
+// mode, the square root of a negative number is imaginary rather than a MathERROR.
+func BuiltinSqrt(env *object.Environment, args ...object.Object) object.Object {
 	if len(args) != 1 {
 		return newError("wrong number of arguments. got=%d, want=1", len(args))
 	}
 
 	var inp float64
 
-	switch val := args[0].(type) {
+	switch val := object.Value(args[0]).(type) {
 	case *object.Float:
 		inp = val.Value
 	case *object.Integer:
 		inp = float64(val.Value)
+	case *object.BigInteger:
+		inp = object.BigIntegerToFloat(val).Value
+	case *object.Complex:
+		return object.NewComplex(cmplx.Sqrt(val.Value))
 	default:
 		return newError("argument to `SQRT` not supported, got=%s", args[0].Type())
 	}
 
+	if inp < 0 && env.ComplexMode() {
+		return object.NewComplex(complex(0, math.Sqrt(-inp)))
+	}
+
 	result := math.Sqrt(float64(inp))
 
 	if math.IsNaN(result) {
@@ -103,36 +114,122 @@ func BuiltinSqrt(args ...object.Object) object.Object {
 	return &object.Float{Value: result}
 }
 
-// BuiltinDelta
+// BuiltinAbs finds the absolute value of an integer, a big integer or a float, the modulus
+// of a complex number or the length of a vector.
+func BuiltinAbs(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	switch val := object.Value(args[0]).(type) {
+	case *object.Float:
+		return &object.Float{Value: math.Abs(val.Value)}
+	case *object.Complex:
+		return &object.Float{Value: cmplx.Abs(val.Value)}
+	case *object.Vector:
+		return &object.Float{Value: norm(val)}
+	case *object.Integer:
+		return object.NewInteger(new(big.Int).Abs(big.NewInt(val.Value)))
+	case *object.BigInteger:
+		return object.NewInteger(new(big.Int).Abs(val.Value))
+	default:
+		return newError("argument to `ABS` not supported, got=%s", args[0].Type())
+	}
+}
+
+// BuiltinKronDelta returns 1 if all of its arguments are equal, and 0 otherwise. Integers are
+// compared exactly, and other numbers, including complex numbers, are equal if they are
+// within FLOAT_EQUALITY_TOL.
 func BuiltinKronDelta(args ...object.Object) object.Object {
 	if len(args) == 0 {
 		return newError("wrong number of arguments. got=0, want:>=1")
 	}
 
-	floatArgs := []float64{}
+	for i, arg := range args {
+		switch object.Value(arg).(type) {
+		case *object.Integer, *object.BigInteger, *object.Float, *object.Complex:
+		default:
+			return newError("argument %d to `DELTA` not supported, got=%s", i+1, arg.Type())
+		}
+	}
 
-	for _, arg := range args {
-		switch arg := arg.(type) {
-		case *object.Float:
-			floatArgs = append(floatArgs, arg.Value)
-		case *object.Integer:
-			floatArgs = append(floatArgs, float64(arg.Value))
+	for _, arg := range args[1:] {
+		if !deltaEqual(args[0], arg) {
+			return &object.Integer{Value: 0}
 		}
 	}
 
-	start := floatArgs[0]
-	same := true
+	return &object.Integer{Value: 1}
+}
 
-	for _, arg := range floatArgs {
-		if math.Abs(arg-start) > FLOAT_EQUALITY_TOL {
-			same = false
-			break
-		}
+// deltaEqual checks whether two arguments to DELTA are equal.
+func deltaEqual(a, b object.Object) bool {
+	x, xExact := exactInteger(a)
+	y, yExact := exactInteger(b)
+
+	if xExact && yExact {
+		return x.Cmp(y) == 0
 	}
 
-	if same {
-		return &object.Integer{Value: 1}
+	z, _ := object.ToComplex(a)
+	w, _ := object.ToComplex(b)
+
+	return cmplx.Abs(z-w) <= FLOAT_EQUALITY_TOL
+}
+
+// exactInteger returns the value of an integer or a big integer. It returns false for any
+// other object.
+func exactInteger(obj object.Object) (*big.Int, bool) {
+	switch obj := object.Value(obj).(type) {
+	case *object.Integer:
+		return big.NewInt(obj.Value), true
+	case *object.BigInteger:
+		return obj.Value, true
+	default:
+		return nil, false
+	}
+}
+
+// integerResult converts a whole number from a calculation into an integer, or a big integer
+// if it is too large for one.
+func integerResult(value float64) object.Object {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return newError("MathERROR")
+	}
+
+	if value >= math.MinInt64 && value < math.MaxInt64 {
+		return &object.Integer{Value: int64(value)}
+	}
+
+	i, _ := big.NewFloat(value).Int(nil)
+	return object.NewInteger(i)
+}
+
+// floatArgument checks that a builtin has been given a single number, and returns it as a
+// float.
+func floatArgument(name string, args []object.Object) (float64, *object.Error) {
+	if len(args) != 1 {
+		return 0, newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	switch val := object.Value(args[0]).(type) {
+	case *object.Float:
+		return val.Value, nil
+	case *object.Integer:
+		return float64(val.Value), nil
+	case *object.BigInteger:
+		return object.BigIntegerToFloat(val).Value, nil
+	default:
+		return 0, newError("argument to `%s` not supported, got=%s", name, args[0].Type())
+	}
+}
+
+// floatResult wraps the result of a calculation in a float, or returns a MathERROR if the
+// result is undefined or too large to represent, like BuiltinSqrt.
+func floatResult(value float64) object.Object {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return newError("MathERROR")
 	}
 
-	return &object.Integer{Value: 0}
+	return &object.Float{Value: value}
 }
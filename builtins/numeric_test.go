@@ -0,0 +1,60 @@
+package builtins_test
+
+import "testing"
+
+func TestRounding(t *testing.T) {
+	testEval(t, []evalTest{
+		{"FLOOR(2.7)", "2"},
+		{"FLOOR(-2.2)", "-3"},
+		{"ROUND(2.5)", "3"},
+		{"CEIL(2.2)", "3"},
+		{"FLOOR(1e20)", "100000000000000000000"},
+		{"FLOOR(NCR(100, 50))", "100891344545564193334812497256"},
+		{"ROUND(NCR(100, 50))", "100891344545564193334812497256"},
+		{"CEIL(NCR(100, 50))", "100891344545564193334812497256"},
+	})
+}
+
+func TestRoundingErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"FLOOR([1, 2])", "argument to `FLOOR` not supported, got=VECTOR"},
+	})
+}
+
+func TestSqrtAndAbs(t *testing.T) {
+	testEval(t, []evalTest{
+		{"SQRT(16)", "4"},
+		{"SQRT(NCR(100, 50))", "317633978890112.1"},
+		{"ABS(-3)", "3"},
+		{"ABS(-NCR(100, 50))", "100891344545564193334812497256"},
+		{"ABS(NCR(100, 50))", "100891344545564193334812497256"},
+		{"ABS(-9223372036854775808)", "9223372036854775808"},
+	})
+}
+
+func TestKronDelta(t *testing.T) {
+	testEval(t, []evalTest{
+		{"DELTA(1)", "1"},
+		{"DELTA(2, 2, 2)", "1"},
+		{"DELTA(2, 2.0000001)", "1"},
+		{"DELTA(2, 3)", "0"},
+		{"DELTA(NCR(100, 50), NCR(100, 50))", "1"},
+		{"DELTA(NCR(100, 50), NCR(100, 50) + 1)", "0"},
+		{"DELTA(1, NCR(100, 50))", "0"},
+		{"DELTA(NCR(100, 50), 1)", "0"},
+		{"DELTA(1+i, 1)", "0"},
+		{"DELTA(1, 1+i)", "0"},
+		{"DELTA(1+i, 1+i)", "1"},
+		{"DELTA(1+i, 1-i)", "0"},
+		{"DELTA(i*i, -1)", "1"},
+	})
+}
+
+func TestKronDeltaErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"DELTA()", "wrong number of arguments. got=0, want:>=1"},
+		{"DELTA(1, [[1, 2], [3, 4]])", "argument 2 to `DELTA` not supported, got=MATRIX"},
+		{"DELTA([1, 2], [1, 2])", "argument 1 to `DELTA` not supported, got=VECTOR"},
+		{"DELTA({1, 2}, {1, 2})", "argument 1 to `DELTA` not supported, got=LIST"},
+	})
+}
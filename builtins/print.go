@@ -7,11 +7,13 @@ import (
 )
 
 // BuiltinPrint will print the value of an expression and return that same expression.
-func BuiltinPrint(args ...object.Object) object.Object {
+// Complex numbers are printed in the environment's complex format, and integers in the
+// BASE-N radix.
+func BuiltinPrint(env *object.Environment, args ...object.Object) object.Object {
 	if len(args) != 1 {
 		return newError("wrong number of arguments. got=%d, want=1", len(args))
 	}
 
-	fmt.Println(args[0].Inspect())
+	fmt.Println(env.Format(args[0]).Inspect())
 	return args[0]
 }
@@ -0,0 +1,81 @@
+package builtins
+
+import (
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// MAX_SERIES_TERMS is the largest number of terms Σ( and Π( will combine before giving up
+// with a MathERROR, so that huge ranges don't hang the program.
+const MAX_SERIES_TERMS int64 = 100000
+
+// BuiltinSigma finds the sum of an expression as a variable ranges over the integers
+// between two bounds, like Σ( on the calculator. For example, Σ(X*X, X, 1, 3) is 14.
+// The variable is bound in its own environment, so it doesn't affect a variable of the
+// same name outside.
+func BuiltinSigma(in object.Interpreter, env *object.Environment, args ...ast.Expression) object.Object {
+	return evalSeries(in, env, "SIGMA", "+", &object.Integer{Value: 0}, args)
+}
+
+// BuiltinProduct finds the product of an expression as a variable ranges over the
+// integers between two bounds, like Π( on the calculator. For example, Π(X, X, 1, 5) is
+// 120.
+func BuiltinProduct(in object.Interpreter, env *object.Environment, args ...ast.Expression) object.Object {
+	return evalSeries(in, env, "PRODUCT", "*", &object.Integer{Value: 1}, args)
+}
+
+// evalSeries combines the values of an expression using an operator, as a variable ranges
+// from a lower bound to an upper bound. If the range is empty, the initial value is
+// returned.
+func evalSeries(in object.Interpreter, env *object.Environment, name, operator string, initial object.Object, args []ast.Expression) object.Object {
+	if len(args) != 4 {
+		return newError("wrong number of arguments. got=%d, want=4", len(args))
+	}
+
+	body := args[0]
+
+	variable, err := variableArgument(name, 1, args[1])
+	if err != nil {
+		return err
+	}
+
+	lower, err := evalIntegerArgument(in, env, name, 2, args[2])
+	if err != nil {
+		return err
+	}
+
+	upper, err := evalIntegerArgument(in, env, name, 3, args[3])
+	if err != nil {
+		return err
+	}
+
+	var terms int64
+	if upper >= lower {
+		if float64(upper)-float64(lower) >= float64(MAX_SERIES_TERMS) {
+			return newError("MathERROR")
+		}
+
+		terms = upper - lower + 1
+	}
+
+	inner := object.NewEnclosedEnvironment(env)
+	result := initial
+
+	for n := int64(0); n < terms; n++ {
+		if set := inner.Set(variable.Value, &object.Integer{Value: lower + n}); isError(set) {
+			return set
+		}
+
+		val := in.Eval(body, inner)
+		if isError(val) {
+			return val
+		}
+
+		result = in.Infix(result, operator, val)
+		if isError(result) {
+			return result
+		}
+	}
+
+	return result
+}
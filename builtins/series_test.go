@@ -0,0 +1,35 @@
+package builtins_test
+
+import "testing"
+
+func TestSeries(t *testing.T) {
+	testEval(t, []evalTest{
+		{"Σ(X*X, X, 1, 3)", "14"},
+		{"SIGMA(X, X, 1, 100)", "5050"},
+		{"Σ(1, X, 1, 100000)", "100000"},
+		{"Σ(X, X, 5, 1)", "0"},
+		{"Σ(X, X, -2, 2)", "0"},
+		{"Σ(1/X, X, 1, 2)", "1.5"},
+		{"Π(X, X, 1, 5)", "120"},
+		{"PRODUCT(X, X, 1, 30)", "265252859812191058636308480000000"},
+		{"Π(X, X, 3, 2)", "1"},
+		{"Σ(1, X, 9223372036854775807 - 1, 9223372036854775807)", "2"},
+
+		{"5 -> X\nΣ(X, X, 1, 3)\nX", "5"},
+		{"5 -> X\nΣ(X, X, 1, 3)", "6"},
+		{"2 -> A\nΣ(A*X, X, 1, 3)", "12"},
+		{"f(N) := Σ(X, X, 1, N)\nf(4)", "10"},
+		{"Σ(Σ(X*Y, Y, 1, 2), X, 1, 2)", "9"},
+	})
+}
+
+func TestSeriesErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"Σ(X, X, 1)", "wrong number of arguments. got=3, want=4"},
+		{"Σ(X, 2, 1, 3)", "ArgumentERROR: argument 2 to `SIGMA` must be a variable, got=2"},
+		{"Σ(X, X, 1.5, 3)", "ArgumentERROR: argument 3 to `SIGMA` must be an integer, got=1.5"},
+		{"Σ(1, X, 1, 1e12)", "MathERROR"},
+		{"Π(1, X, 1, 100001)", "MathERROR"},
+		{"Σ(1/(X-2), X, 1, 3)", "division error: division by zero"},
+	})
+}
@@ -0,0 +1,140 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// SOLVE_TOL is how small a Newton step has to be, relative to the root, for SOLVE to
+// consider it converged.
+const SOLVE_TOL float64 = 1e-12
+
+// MAX_SOLVE_ITERATIONS is the number of Newton steps SOLVE takes before giving up.
+const MAX_SOLVE_ITERATIONS int = 200
+
+// BuiltinSolve finds a root of an expression or equation, like SOLVE on the calculator.
+// The first argument is either an expression, which is solved for zero, or an equation
+// such as X*X = 2. The second argument is the variable to solve for, and the optional
+// third argument is the initial guess, which is otherwise the variable's current value.
+//
+// The root is stored back into the variable, and the result shows both the root and the
+// difference between the left and right hand sides at the root, like L-R on the
+// calculator.
+func BuiltinSolve(in object.Interpreter, env *object.Environment, args ...ast.Expression) object.Object {
+	if len(args) != 2 && len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=2 or 3", len(args))
+	}
+
+	variable, err := variableArgument("SOLVE", 1, args[1])
+	if err != nil {
+		return err
+	}
+
+	guess := 0.0
+	if len(args) == 3 {
+		guess, err = evalFloatArgument(in, env, "SOLVE", 2, args[2])
+		if err != nil {
+			return err
+		}
+	} else if _, ok := env.Get(variable.Value); ok {
+		guess, err = evalFloatArgument(in, env, "SOLVE", 1, variable)
+		if err != nil {
+			return err
+		}
+	}
+
+	f := function(in, env, "SOLVE", args[0], variable)
+
+	// An equation L = R is solved by finding a root of L - R.
+	if eq, ok := args[0].(*ast.InfixExpression); ok && eq.Operator == "=" {
+		left := function(in, env, "SOLVE", eq.Left, variable)
+		right := function(in, env, "SOLVE", eq.Right, variable)
+
+		f = func(x float64) (float64, *object.Error) {
+			l, err := left(x)
+			if err != nil {
+				return 0, err
+			}
+
+			r, err := right(x)
+			if err != nil {
+				return 0, err
+			}
+
+			return l - r, nil
+		}
+	}
+
+	root, err := newton(f, guess)
+	if err != nil {
+		return err
+	}
+
+	residual, err := f(root)
+	if err != nil {
+		return err
+	}
+
+	result := &object.Float{Value: root}
+	if set := env.Set(variable.Value, result); isError(set) {
+		return set
+	}
+
+	return &object.Solution{
+		Variable: variable.Value,
+		Root:     result,
+		Residual: &object.Float{Value: residual},
+	}
+}
+
+// newton finds a root of f using Newton's method starting from a guess, estimating the
+// derivative with a central difference. It gives a Can't Solve error if it doesn't
+// converge.
+func newton(f func(float64) (float64, *object.Error), guess float64) (float64, *object.Error) {
+	x := guess
+
+	for i := 0; i < MAX_SOLVE_ITERATIONS; i++ {
+		y, err := f(x)
+		if err != nil {
+			return 0, err
+		}
+
+		if y == 0 {
+			return x, nil
+		}
+
+		h := 1e-6 * math.Max(1, math.Abs(x))
+
+		right, err := f(x + h)
+		if err != nil {
+			return 0, err
+		}
+
+		left, err := f(x - h)
+		if err != nil {
+			return 0, err
+		}
+
+		slope := (right - left) / (2 * h)
+		if slope == 0 || math.IsNaN(slope) || math.IsInf(slope, 0) {
+			// On a flat spot, nudge the guess and try again.
+			x += h * 1000
+			continue
+		}
+
+		step := y / slope
+		x -= step
+
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			break
+		}
+
+		if math.Abs(step) <= SOLVE_TOL*math.Max(1, math.Abs(x)) {
+			return x, nil
+		}
+	}
+
+	return 0, newError("Can't Solve: no root found starting from %v", guess)
+}
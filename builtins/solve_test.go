@@ -0,0 +1,29 @@
+package builtins_test
+
+import "testing"
+
+func TestSolve(t *testing.T) {
+	testEval(t, []evalTest{
+		{"SOLVE(2*X + 1 = 0, X)", "X=-0.5, L-R=0"},
+		{"SOLVE(2*X + 1, X)", "X=-0.5, L-R=0"},
+		{"3 -> X\nSOLVE(X*X = 9, X)", "X=3, L-R=0"},
+		{"SOLVE(X*X = 2, X, 1)\nX", "1.414213562373095"},
+		{"SOLVE(X*X = 2, X, -1)\nX", "-1.414213562373095"},
+		{"SOLVE(X*X = 2, X, 1) * 2", "2.82842712474619"},
+		{"%rad\nSOLVE(COS(X) = X, X, 1)\nX", "0.739085133215161"},
+		{"f(A) := A*A - 4\nSOLVE(f(X), X, 1)", "X=2, L-R=0"},
+	})
+}
+
+func TestSolveErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"SOLVE(X*X + 1, X, 1)", "Can't Solve: no root found starting from 1"},
+		{"SOLVE(1/X = 0, X, 1)", "Can't Solve: no root found starting from 1"},
+		{"SOLVE(1, 2)", "ArgumentERROR: argument 2 to `SOLVE` must be a variable, got=2"},
+		{"SOLVE(X, X, 1, 2)", "wrong number of arguments. got=4, want=2 or 3"},
+		{"SOLVE(Y*Y = 4, X, 1)", "identifier not found: Y"},
+		{"1 = 2", "equations can only be used inside SOLVE, got=1 = 2"},
+		{"1.5 = 2", "equations can only be used inside SOLVE, got=1.5 = 2"},
+		{"NCR(100, 50) = NCR(100, 50)", "equations can only be used inside SOLVE, got=100891344545564193334812497256 = 100891344545564193334812497256"},
+	})
+}
@@ -0,0 +1,566 @@
+package builtins
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// summary holds the sums that single-variable statistics are calculated from, where each
+// value is weighted by its frequency.
+type summary struct {
+	n          float64 // the number of values
+	sum        float64 // Σx
+	sumSquares float64 // Σx²
+	deviations float64 // Σ(x - x̄)², which is more accurate than Σx² - (Σx)²/n
+}
+
+// STAT_FIGURES is the number of significant figures regression coefficients are rounded
+// to, so that fitting {1, 2, 3} and {2, 4, 6} gives b=2 rather than 1.9999999999999998.
+const STAT_FIGURES int = 15
+
+// BuiltinMean finds the mean of a list, x̄. An optional second list gives the frequency of
+// each value.
+func BuiltinMean(args ...object.Object) object.Object {
+	return oneVariable("MEAN", args, func(s summary) float64 {
+		return s.sum / s.n
+	})
+}
+
+// BuiltinSigmaX finds the population standard deviation of a list, σx.
+func BuiltinSigmaX(args ...object.Object) object.Object {
+	return oneVariable("SIGMAX", args, func(s summary) float64 {
+		return math.Sqrt(s.deviations / s.n)
+	})
+}
+
+// BuiltinSX finds the sample standard deviation of a list, sx. It needs more than one
+// value.
+func BuiltinSX(args ...object.Object) object.Object {
+	return oneVariable("SX", args, func(s summary) float64 {
+		if s.n <= 1 {
+			return math.NaN()
+		}
+
+		return math.Sqrt(s.deviations / (s.n - 1))
+	})
+}
+
+// BuiltinSumX finds the sum of a list, Σx.
+func BuiltinSumX(args ...object.Object) object.Object {
+	return oneVariable("SUMX", args, func(s summary) float64 {
+		return s.sum
+	})
+}
+
+// BuiltinSumX2 finds the sum of the squares of a list, Σx².
+func BuiltinSumX2(args ...object.Object) object.Object {
+	return oneVariable("SUMX2", args, func(s summary) float64 {
+		return s.sumSquares
+	})
+}
+
+// BuiltinCount finds the number of values in a list, n, taking frequencies into account.
+func BuiltinCount(args ...object.Object) object.Object {
+	return oneVariable("COUNT", args, func(s summary) float64 {
+		return s.n
+	})
+}
+
+// BuiltinMinX finds the smallest value in a list.
+func BuiltinMinX(args ...object.Object) object.Object {
+	return extreme("MINX", args, func(x, best float64) bool { return x < best })
+}
+
+// BuiltinMaxX finds the largest value in a list.
+func BuiltinMaxX(args ...object.Object) object.Object {
+	return extreme("MAXX", args, func(x, best float64) bool { return x > best })
+}
+
+// BuiltinSum adds up the values in a list, like Sum( in SPREADSHEET mode. Unlike SUMX,
+// an empty list, such as a range of empty cells, adds up to 0.
+func BuiltinSum(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	xs, err := listArgument("SUM", args, 0)
+	if err != nil {
+		return err
+	}
+
+	total := 0.0
+	for _, x := range xs {
+		total += x
+	}
+
+	return floatResult(total)
+}
+
+// BuiltinMin finds the smallest value in a list, like Min( in SPREADSHEET mode.
+func BuiltinMin(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	return extreme("MIN", args, func(x, best float64) bool { return x < best })
+}
+
+// BuiltinMax finds the largest value in a list, like Max( in SPREADSHEET mode.
+func BuiltinMax(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	return extreme("MAX", args, func(x, best float64) bool { return x > best })
+}
+
+// BuiltinLinReg fits a straight line, y=a+bx, to two lists of data.
+func BuiltinLinReg(args ...object.Object) object.Object {
+	return linearizedRegression("LINREG", object.LINEAR_REG, args, identity, identity)
+}
+
+// BuiltinLogReg fits a logarithmic curve, y=a+b·ln(x), to two lists of data. Every x value
+// must be positive.
+func BuiltinLogReg(args ...object.Object) object.Object {
+	return linearizedRegression("LOGREG", object.LOGARITHMIC_REG, args, math.Log, identity)
+}
+
+// BuiltinExpReg fits an exponential curve, y=a·e^(bx), to two lists of data. Every y value
+// must be positive.
+func BuiltinExpReg(args ...object.Object) object.Object {
+	return linearizedRegression("EXPREG", object.EXPONENTIAL_REG, args, identity, math.Log)
+}
+
+// BuiltinPwrReg fits a power curve, y=a·x^b, to two lists of data. Every x and y value must
+// be positive.
+func BuiltinPwrReg(args ...object.Object) object.Object {
+	return linearizedRegression("PWRREG", object.POWER_REG, args, math.Log, math.Log)
+}
+
+// BuiltinQuadReg fits a parabola, y=a+bx+cx², to two lists of data using least squares.
+// Quadratic regressions don't have a correlation coefficient.
+func BuiltinQuadReg(args ...object.Object) object.Object {
+	xs, ys, err := pairedData("QUADREG", args, 3)
+	if err != nil {
+		return err
+	}
+
+	// The curve is fitted to x values shifted by their mean, which keeps the normal equations
+	// well conditioned, and then shifted back.
+	m := mean(xs)
+
+	var powers [5]float64 // Σu⁰ to Σu⁴, where u = x - x̄
+	var rhs [3]float64    // Σy, Σuy and Σu²y
+
+	for i := range xs {
+		x := xs[i] - m
+
+		for k := range powers {
+			powers[k] += math.Pow(x, float64(k))
+		}
+
+		for k := range rhs {
+			rhs[k] += math.Pow(x, float64(k)) * ys[i]
+		}
+	}
+
+	normal := object.NewMatrix(3, 3)
+	for i := range normal.Values {
+		for j := range normal.Values[i] {
+			normal.Values[i][j] = powers[i+j]
+		}
+	}
+
+	det, inverse := eliminate(normal)
+	if det == 0 {
+		return newError("MathERROR")
+	}
+
+	shifted := make([]float64, 3)
+	for i, row := range inverse.Values {
+		for j, x := range row {
+			shifted[i] += x * rhs[j]
+		}
+	}
+
+	// Shifting back can cancel large terms, so each coefficient is rounded relative to the
+	// terms it was made from.
+	a, b, c := shifted[0], shifted[1], shifted[2]
+	coefficients := []float64{
+		roundToMagnitude(a-b*m+c*m*m, largest(a, b*m, c*m*m), STAT_FIGURES),
+		roundToMagnitude(b-2*c*m, largest(b, 2*c*m), STAT_FIGURES),
+		c,
+	}
+
+	return newRegression(object.QUADRATIC_REG, coefficients, math.NaN())
+}
+
+// BuiltinYHat estimates the y value for a given x value using a regression, ŷ.
+func BuiltinYHat(args ...object.Object) object.Object {
+	reg, x, err := estimateArguments("YHAT", args)
+	if err != nil {
+		return err
+	}
+
+	a, b := reg.Coefficients[0], reg.Coefficients[1]
+
+	switch reg.Model {
+	case object.QUADRATIC_REG:
+		return floatResult(a + b*x + reg.Coefficients[2]*x*x)
+	case object.LOGARITHMIC_REG:
+		if x <= 0 {
+			return newError("MathERROR")
+		}
+
+		return floatResult(a + b*math.Log(x))
+	case object.EXPONENTIAL_REG:
+		return floatResult(a * math.Exp(b*x))
+	case object.POWER_REG:
+		return floatResult(a * math.Pow(x, b))
+	default:
+		return floatResult(a + b*x)
+	}
+}
+
+// BuiltinXHat estimates the x value for a given y value using a regression, x̂. A quadratic
+// regression has two estimates, x̂1 and x̂2, which are returned as a list.
+func BuiltinXHat(args ...object.Object) object.Object {
+	reg, y, err := estimateArguments("XHAT", args)
+	if err != nil {
+		return err
+	}
+
+	a, b := reg.Coefficients[0], reg.Coefficients[1]
+
+	switch reg.Model {
+	case object.QUADRATIC_REG:
+		c := reg.Coefficients[2]
+		if c == 0 {
+			return floatResult((y - a) / b)
+		}
+
+		discriminant := b*b - 4*c*(a-y)
+		if discriminant < 0 {
+			return newError("MathERROR")
+		}
+
+		roots := []float64{
+			(-b - math.Sqrt(discriminant)) / (2 * c),
+			(-b + math.Sqrt(discriminant)) / (2 * c),
+		}
+		sort.Float64s(roots)
+
+		return &object.List{Values: roots}
+	case object.LOGARITHMIC_REG:
+		return floatResult(math.Exp((y - a) / b))
+	case object.EXPONENTIAL_REG:
+		return floatResult(math.Log(y/a) / b)
+	case object.POWER_REG:
+		return floatResult(math.Pow(y/a, 1/b))
+	default:
+		return floatResult((y - a) / b)
+	}
+}
+
+// BuiltinRegA finds the coefficient a of a regression.
+func BuiltinRegA(args ...object.Object) object.Object {
+	return coefficient("REGA", args, 0)
+}
+
+// BuiltinRegB finds the coefficient b of a regression.
+func BuiltinRegB(args ...object.Object) object.Object {
+	return coefficient("REGB", args, 1)
+}
+
+// BuiltinRegC finds the coefficient c of a quadratic regression.
+func BuiltinRegC(args ...object.Object) object.Object {
+	return coefficient("REGC", args, 2)
+}
+
+// BuiltinRegR finds the correlation coefficient r of a regression.
+func BuiltinRegR(args ...object.Object) object.Object {
+	reg, err := regressionArgument("REGR", args)
+	if err != nil {
+		return err
+	}
+
+	if reg.Model == object.QUADRATIC_REG {
+		return newError("ArgumentERROR: %s regressions have no correlation coefficient", reg.Model)
+	}
+
+	// r is undefined when the y values are all the same.
+	return floatResult(reg.R)
+}
+
+// oneVariable calculates a single-variable statistic from the summary of a list.
+func oneVariable(name string, args []object.Object, stat func(s summary) float64) object.Object {
+	xs, freqs, err := frequencyData(name, args)
+	if err != nil {
+		return err
+	}
+
+	var s summary
+	for i, x := range xs {
+		s.n += freqs[i]
+		s.sum += freqs[i] * x
+		s.sumSquares += freqs[i] * x * x
+	}
+
+	if s.n == 0 {
+		return newError("MathERROR")
+	}
+
+	average := s.sum / s.n
+	for i, x := range xs {
+		s.deviations += freqs[i] * (x - average) * (x - average)
+	}
+
+	return floatResult(stat(s))
+}
+
+// extreme finds the value in a list that is better than every other, ignoring values with
+// a frequency of zero.
+func extreme(name string, args []object.Object, better func(x, best float64) bool) object.Object {
+	xs, freqs, err := frequencyData(name, args)
+	if err != nil {
+		return err
+	}
+
+	found, best := false, 0.0
+	for i, x := range xs {
+		if freqs[i] > 0 && (!found || better(x, best)) {
+			found, best = true, x
+		}
+	}
+
+	if !found {
+		return newError("MathERROR")
+	}
+
+	return floatResult(best)
+}
+
+// linearizedRegression fits a curve that becomes a straight line once its x and y values
+// are transformed, such as taking the logarithm of y for an exponential curve. The a
+// coefficient is transformed back if the y values were.
+func linearizedRegression(name string, model object.RegressionModel, args []object.Object, fx, fy func(float64) float64) object.Object {
+	xs, ys, err := pairedData(name, args, 2)
+	if err != nil {
+		return err
+	}
+
+	tx := make([]float64, len(xs))
+	ty := make([]float64, len(ys))
+
+	for i := range xs {
+		tx[i], ty[i] = fx(xs[i]), fy(ys[i])
+		if math.IsNaN(tx[i]) || math.IsInf(tx[i], 0) || math.IsNaN(ty[i]) || math.IsInf(ty[i], 0) {
+			return newError("MathERROR")
+		}
+	}
+
+	// The sums are taken about the means, which loses less precision than using Σx² - (Σx)²/n.
+	meanX, meanY := mean(tx), mean(ty)
+	sxx, syy, sxy := 0.0, 0.0, 0.0
+
+	for i := range tx {
+		dx, dy := tx[i]-meanX, ty[i]-meanY
+		sxx += dx * dx
+		syy += dy * dy
+		sxy += dx * dy
+	}
+
+	if sxx == 0 {
+		return newError("MathERROR")
+	}
+
+	b := sxy / sxx
+	a := meanY - b*meanX
+
+	if model == object.EXPONENTIAL_REG || model == object.POWER_REG {
+		a = math.Exp(a)
+	}
+
+	// When the y values are all the same, r is 0/0, so it is left undefined.
+	r := math.NaN()
+	if syy != 0 {
+		r = math.Max(-1, math.Min(1, sxy/math.Sqrt(sxx*syy)))
+	}
+
+	return newRegression(model, []float64{a, b}, r)
+}
+
+// newRegression creates a regression, rounding its coefficients to STAT_FIGURES.
+func newRegression(model object.RegressionModel, coefficients []float64, r float64) object.Object {
+	for i, x := range coefficients {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return newError("MathERROR")
+		}
+
+		coefficients[i] = object.RoundSignificant(x, STAT_FIGURES)
+
+		// Rounding a tiny negative coefficient gives -0, which shouldn't be displayed.
+		if coefficients[i] == 0 {
+			coefficients[i] = 0
+		}
+	}
+
+	if !math.IsNaN(r) {
+		r = object.RoundSignificant(r, STAT_FIGURES)
+	}
+
+	return &object.Regression{Model: model, Coefficients: coefficients, R: r}
+}
+
+// coefficient finds one of the coefficients of a regression.
+func coefficient(name string, args []object.Object, i int) object.Object {
+	reg, err := regressionArgument(name, args)
+	if err != nil {
+		return err
+	}
+
+	if i >= len(reg.Coefficients) {
+		return newError("ArgumentERROR: %s regressions have no coefficient %c", reg.Model, 'a'+i)
+	}
+
+	return floatResult(reg.Coefficients[i])
+}
+
+// frequencyData checks that a builtin has been given a list, and optionally a second list
+// of the same length giving the frequency of each value. Without one, every value has a
+// frequency of 1.
+func frequencyData(name string, args []object.Object) ([]float64, []float64, *object.Error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, nil, newError("wrong number of arguments. got=%d, want=1 or 2", len(args))
+	}
+
+	xs, err := listArgument(name, args, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(args) == 1 {
+		freqs := make([]float64, len(xs))
+		for i := range freqs {
+			freqs[i] = 1
+		}
+
+		return xs, freqs, nil
+	}
+
+	freqs, err := listArgument(name, args, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(freqs) != len(xs) {
+		return nil, nil, newError("Dimension ERROR: `%s` needs a frequency for every value, got=%d values and %d frequencies", name, len(xs), len(freqs))
+	}
+
+	for _, f := range freqs {
+		if f < 0 {
+			return nil, nil, newError("ArgumentERROR: frequencies can't be negative, got=%s", (&object.Float{Value: f}).Inspect())
+		}
+	}
+
+	return xs, freqs, nil
+}
+
+// pairedData checks that a builtin has been given two lists of x and y values with the
+// same length, with at least the given number of points.
+func pairedData(name string, args []object.Object, min int) ([]float64, []float64, *object.Error) {
+	if len(args) != 2 {
+		return nil, nil, newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	xs, err := listArgument(name, args, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ys, err := listArgument(name, args, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(xs) != len(ys) {
+		return nil, nil, newError("Dimension ERROR: `%s` needs lists with the same number of elements, got=%d and %d", name, len(xs), len(ys))
+	}
+
+	if len(xs) < min {
+		return nil, nil, newError("ArgumentERROR: `%s` needs at least %d points, got=%d", name, min, len(xs))
+	}
+
+	return xs, ys, nil
+}
+
+// listArgument checks that the argument at the given index is a list.
+func listArgument(name string, args []object.Object, i int) ([]float64, *object.Error) {
+	l, ok := object.Value(args[i]).(*object.List)
+	if !ok {
+		return nil, newError("argument %d to `%s` not supported, got=%s", i+1, name, args[i].Type())
+	}
+
+	return l.Values, nil
+}
+
+// regressionArgument checks that a builtin has been given a single regression.
+func regressionArgument(name string, args []object.Object) (*object.Regression, *object.Error) {
+	if len(args) != 1 {
+		return nil, newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	reg, ok := object.Value(args[0]).(*object.Regression)
+	if !ok {
+		return nil, newError("argument to `%s` not supported, got=%s", name, args[0].Type())
+	}
+
+	return reg, nil
+}
+
+// estimateArguments checks that a builtin has been given a regression and a number to
+// estimate from.
+func estimateArguments(name string, args []object.Object) (*object.Regression, float64, *object.Error) {
+	if len(args) != 2 {
+		return nil, 0, newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	reg, err := regressionArgument(name, args[:1])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	x, err := floatArgument(name, args[1:])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reg, x, nil
+}
+
+// mean finds the mean of a non-empty list of values.
+func mean(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+
+	return sum / float64(len(xs))
+}
+
+// largest finds the value with the largest magnitude.
+func largest(values ...float64) float64 {
+	max := 0.0
+	for _, x := range values {
+		max = math.Max(max, math.Abs(x))
+	}
+
+	return max
+}
+
+// identity returns its argument unchanged, for regressions that don't transform x or y.
+func identity(x float64) float64 {
+	return x
+}
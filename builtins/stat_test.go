@@ -0,0 +1,61 @@
+package builtins_test
+
+import "testing"
+
+func TestStatistics(t *testing.T) {
+	testEval(t, []evalTest{
+		{"MEAN({1, 2, 3, 4})", "2.5"},
+		{"MEAN({1, 2, 3}, {1, 1, 2})", "2.25"},
+		{"SIGMAX({2, 4, 4, 4, 5, 5, 7, 9})", "2"},
+		{"SX({2, 4, 4, 4, 5, 5, 7, 9})", "2.138089935299395"},
+		{"SX({1, 2}, {1, 3})", "0.5"},
+		{"SUMX({1, 2, 3}, {2, 1, 1})", "7"},
+		{"SUMX2({1, 2, 3})", "14"},
+		{"COUNT({1, 2}, {3, 4})", "7"},
+		{"MINX({3, 1, 2})", "1"},
+		{"MAXX({3, 1, 2})", "3"},
+		{"SUM({1, 2, 3})", "6"},
+		{"MIN({3, 1, 2})", "1"},
+		{"MAX({3, 1, 2})", "3"},
+	})
+}
+
+func TestRegressions(t *testing.T) {
+	testEval(t, []evalTest{
+		{"LINREG({1, 2, 3, 4}, {3, 5, 7, 9})", "y=a+bx, a=1, b=2, r=1"},
+		{"REGA(LINREG({1, 2, 3, 4}, {3, 5, 7, 10}))", "0.5"},
+		{"REGB(LINREG({1, 2, 3, 4}, {3, 5, 7, 10}))", "2.3"},
+		{"REGR(LINREG({1, 2, 3, 4}, {3, 5, 7, 10}))", "0.994376712684369"},
+		{"LINREG({1, 2, 3}, {5, 5, 5})", "y=a+bx, a=5, b=0"},
+		{"QUADREG({0, 1, 2, 3}, {1, 2, 5, 10})", "y=a+bx+cx², a=1, b=0, c=1"},
+		{"REGC(QUADREG({0, 1, 2, 3}, {1, 2, 5, 10}))", "1"},
+		{"EXPREG({0, 1, 2}, {1, e, e*e})", "y=a·e^(bx), a=1, b=1, r=1"},
+		{"LOGREG({1, e, e*e}, {1, 3, 5})", "y=a+b·ln(x), a=1, b=2, r=1"},
+		{"PWRREG({1, 2, 4}, {3, 12, 48})", "y=a·x^b, a=3, b=2, r=1"},
+
+		{"YHAT(LINREG({1, 2, 3}, {2, 4, 6}), 10)", "20"},
+		{"XHAT(LINREG({1, 2, 3}, {2, 4, 6}), 10)", "5"},
+		{"XHAT(QUADREG({0, 1, 2, 3}, {0, 1, 4, 9}), 4)", "{-2, 2}"},
+		{"YHAT(PWRREG({1, 2, 4}, {3, 12, 48}), 3)", "27"},
+	})
+}
+
+func TestStatisticsErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"MEAN({})", "MathERROR"},
+		{"MEAN({1, 2}, {0, 0})", "MathERROR"},
+		{"SX({5})", "MathERROR"},
+		{"MEAN({1, 2}, {1})", "Dimension ERROR: `MEAN` needs a frequency for every value, got=2 values and 1 frequencies"},
+		{"MEAN({1, 2}, {1, -1})", "ArgumentERROR: frequencies can't be negative, got=-1"},
+		{"LINREG({1, 2}, {1})", "Dimension ERROR: `LINREG` needs lists with the same number of elements, got=2 and 1"},
+		{"LINREG({1}, {1})", "ArgumentERROR: `LINREG` needs at least 2 points, got=1"},
+		{"LINREG({1, 1, 1}, {1, 2, 3})", "MathERROR"},
+		{"REGR(LINREG({1, 2, 3}, {5, 5, 5}))", "MathERROR"},
+		{"REGR(EXPREG({1, 2, 3}, {5, 5, 5}))", "MathERROR"},
+		{"EXPREG({1, 2}, {1, -1})", "MathERROR"},
+		{"LOGREG({0, 1}, {1, 2})", "MathERROR"},
+		{"PWRREG({1, 2}, {0, 1})", "MathERROR"},
+		{"REGR(QUADREG({0, 1, 2, 3}, {1, 2, 5, 10}))", "ArgumentERROR: y=a+bx+cx² regressions have no correlation coefficient"},
+		{"REGC(LINREG({1, 2}, {1, 2}))", "ArgumentERROR: y=a+bx regressions have no coefficient c"},
+	})
+}
@@ -0,0 +1,154 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// QUADRANT_TOL is how close an angle has to be to a multiple of π/2 for the trigonometric
+// functions to treat it as exact. This makes SIN(180) exactly 0 in DEG, like the device.
+const QUADRANT_TOL float64 = 1e-12
+
+// MAX_TRIG_QUADRANTS is the size of the largest angle the trigonometric functions accept,
+// as a number of quarter turns. This matches the device's limit of 9×10⁹ degrees; beyond
+// it, there are too few floats between multiples of π/2 to tell whether an angle is one.
+const MAX_TRIG_QUADRANTS float64 = 1e8
+
+// TRIG_FIGURES is the number of significant figures the results of the trigonometric
+// functions are rounded to, hiding floating point noise such as SIN(30) = 0.49999999999999994.
+const TRIG_FIGURES int = 15
+
+// BuiltinSin finds the sine of an angle. The angle has already been converted into radians.
+func BuiltinSin(args ...object.Object) object.Object {
+	x, err := trigArgument("SIN", args)
+	if err != nil {
+		return err
+	}
+
+	return &object.Float{Value: exactSin(x)}
+}
+
+// BuiltinCos finds the cosine of an angle. The angle has already been converted into radians.
+func BuiltinCos(args ...object.Object) object.Object {
+	x, err := trigArgument("COS", args)
+	if err != nil {
+		return err
+	}
+
+	return &object.Float{Value: exactCos(x)}
+}
+
+// BuiltinTan finds the tangent of an angle. The angle has already been converted into
+// radians. Odd multiples of 90 degrees cause a MathERROR.
+func BuiltinTan(args ...object.Object) object.Object {
+	x, err := trigArgument("TAN", args)
+	if err != nil {
+		return err
+	}
+
+	if quadrant, ok := exactQuadrant(x); ok {
+		if quadrant%2 == 1 {
+			return newError("MathERROR")
+		}
+
+		return &object.Float{Value: 0}
+	}
+
+	return &object.Float{Value: object.RoundSignificant(math.Tan(x), TRIG_FIGURES)}
+}
+
+// BuiltinAsin finds the inverse sine of a number, as an angle in radians.
+func BuiltinAsin(args ...object.Object) object.Object {
+	x, err := floatArgument("ASIN", args)
+	if err != nil {
+		return err
+	}
+
+	if x < -1 || x > 1 {
+		return newError("MathERROR")
+	}
+
+	return &object.Float{Value: math.Asin(x)}
+}
+
+// BuiltinAcos finds the inverse cosine of a number, as an angle in radians.
+func BuiltinAcos(args ...object.Object) object.Object {
+	x, err := floatArgument("ACOS", args)
+	if err != nil {
+		return err
+	}
+
+	if x < -1 || x > 1 {
+		return newError("MathERROR")
+	}
+
+	return &object.Float{Value: math.Acos(x)}
+}
+
+// BuiltinAtan finds the inverse tangent of a number, as an angle in radians.
+func BuiltinAtan(args ...object.Object) object.Object {
+	x, err := floatArgument("ATAN", args)
+	if err != nil {
+		return err
+	}
+
+	return &object.Float{Value: math.Atan(x)}
+}
+
+// trigArgument checks that a trigonometric function has been given a single angle in
+// radians, and gives a MathERROR if it is too large.
+func trigArgument(name string, args []object.Object) (float64, *object.Error) {
+	x, err := floatArgument(name, args)
+	if err != nil {
+		return 0, err
+	}
+
+	if !(math.Abs(x) < MAX_TRIG_QUADRANTS*math.Pi/2) {
+		return 0, newError("MathERROR")
+	}
+
+	return x, nil
+}
+
+// exactSin finds the sine of an angle in radians, giving exact results for multiples of
+// π/2.
+func exactSin(x float64) float64 {
+	if quadrant, ok := exactQuadrant(x); ok {
+		return []float64{0, 1, 0, -1}[quadrant]
+	}
+
+	return object.RoundSignificant(math.Sin(x), TRIG_FIGURES)
+}
+
+// exactCos finds the cosine of an angle in radians, giving exact results for multiples of
+// π/2.
+func exactCos(x float64) float64 {
+	if quadrant, ok := exactQuadrant(x); ok {
+		return []float64{1, 0, -1, 0}[quadrant]
+	}
+
+	return object.RoundSignificant(math.Cos(x), TRIG_FIGURES)
+}
+
+// exactQuadrant checks if an angle in radians is a multiple of π/2, and if it is returns
+// which one it is modulo 4. For example, π is 2 and -π/2 is 3.
+func exactQuadrant(x float64) (int, bool) {
+	k := x / (math.Pi / 2)
+	rounded := math.Round(k)
+
+	if !(math.Abs(k) < MAX_TRIG_QUADRANTS) {
+		return 0, false
+	}
+
+	// Angles close to zero are left alone, as they are already as accurate as they can be.
+	if rounded == 0 {
+		return 0, x == 0
+	}
+
+	if math.Abs(k-rounded) > QUADRANT_TOL*math.Abs(k) {
+		return 0, false
+	}
+
+	return int(math.Mod(math.Mod(rounded, 4)+4, 4)), true
+}
@@ -0,0 +1,49 @@
+package builtins_test
+
+import "testing"
+
+func TestTrigonometry(t *testing.T) {
+	testEval(t, []evalTest{
+		{"SIN(30)", "0.5"},
+		{"SIN(180)", "0"},
+		{"SIN(-90)", "-1"},
+		{"COS(60)", "0.5"},
+		{"COS(90)", "0"},
+		{"COS(180)", "-1"},
+		{"TAN(45)", "1"},
+		{"TAN(180)", "0"},
+		{"SIN(8999999999)", "-0.017452416528785"},
+		{"SIN(5400000000)", "0"},
+
+		{"%rad\nSIN(pi)", "0"},
+		{"%rad\nCOS(pi/3)", "0.5"},
+		{"%grad\nSIN(100)", "1"},
+		{"%grad\nCOS(200)", "-1"},
+
+		{"ASIN(1)", "90"},
+		{"ACOS(0.5)", "60"},
+		{"ATAN(1)", "45"},
+		{"ATAN(1e300)", "90"},
+		{"%rad\nASIN(1)", "1.5707963267948966"},
+		{"%rad\nATAN(1)", "0.7853981633974483"},
+		{"%grad\nACOS(0)", "100"},
+	})
+}
+
+func TestTrigonometryErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"TAN(90)", "MathERROR"},
+		{"TAN(-270)", "MathERROR"},
+		{"%rad\nTAN(pi/2)", "MathERROR"},
+		{"%grad\nTAN(100)", "MathERROR"},
+		{"SIN(1e20)", "MathERROR"},
+		{"SIN(9e9)", "MathERROR"},
+		{"COS(-9e9)", "MathERROR"},
+		{"TAN(1e20)", "MathERROR"},
+		{"SIN(NCR(100, 50))", "MathERROR"},
+		{"ASIN(2)", "MathERROR"},
+		{"ACOS(-1.5)", "MathERROR"},
+		{"SIN([1, 2])", "argument to `SIN` not supported, got=VECTOR"},
+		{"SIN(1, 2)", "wrong number of arguments. got=2, want=1"},
+	})
+}
@@ -0,0 +1,202 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// CONV_FIGURES is the number of significant figures conversions are rounded to, hiding
+// floating point error such as 2 L giving 2000.0000000000002 mL.
+const CONV_FIGURES int = 15
+
+// AFFINE_FIGURES is the number of significant figures, measured from absolute zero, that
+// conversions between temperatures like °C and °F are rounded to.
+const AFFINE_FIGURES int = 13
+
+// Unit represents a unit that CONV can convert between. A value in the unit is converted
+// into the SI unit for its dimension by multiplying by Factor and then adding Offset, which
+// is only non-zero for temperatures like °C and °F.
+type Unit struct {
+	Dimension string
+	Factor    float64
+	Offset    float64
+}
+
+// Dimensions of units, which CONV can only convert between when they are the same.
+const (
+	DIM_LENGTH      = "length"
+	DIM_AREA        = "area"
+	DIM_VOLUME      = "volume"
+	DIM_VELOCITY    = "velocity"
+	DIM_MASS        = "mass"
+	DIM_FORCE       = "force"
+	DIM_PRESSURE    = "pressure"
+	DIM_ENERGY      = "energy"
+	DIM_POWER       = "power"
+	DIM_TEMPERATURE = "temperature"
+)
+
+// Exact definitions used by several units.
+const (
+	inch     = 0.0254
+	pound    = 0.45359237
+	gravity  = 9.80665
+	calorie  = 4.184
+	usGallon = 3.785411784e-3
+	ukGallon = 4.54609e-3
+	parsec   = 3.0856775814913673e16
+)
+
+// Units is the table of units CONV knows about, covering the conversions in the
+// calculator's CONV menu. Units are written as in CONV(1, in, cm), with powers written
+// as digits, such as cm2, and compound units written with a slash, such as km/h.
+var Units = map[string]Unit{
+	// Length, in metres.
+	"m":     {DIM_LENGTH, 1, 0},
+	"cm":    {DIM_LENGTH, 0.01, 0},
+	"mm":    {DIM_LENGTH, 0.001, 0},
+	"km":    {DIM_LENGTH, 1000, 0},
+	"in":    {DIM_LENGTH, inch, 0},
+	"ft":    {DIM_LENGTH, 12 * inch, 0},
+	"yd":    {DIM_LENGTH, 36 * inch, 0},
+	"mile":  {DIM_LENGTH, 63360 * inch, 0},
+	"nmile": {DIM_LENGTH, 1852, 0},
+	"pc":    {DIM_LENGTH, parsec, 0},
+
+	// Area, in square metres.
+	"m2":   {DIM_AREA, 1, 0},
+	"cm2":  {DIM_AREA, 1e-4, 0},
+	"km2":  {DIM_AREA, 1e6, 0},
+	"acre": {DIM_AREA, 4046.8564224, 0},
+
+	// Volume, in cubic metres.
+	"m3":    {DIM_VOLUME, 1, 0},
+	"L":     {DIM_VOLUME, 1e-3, 0},
+	"mL":    {DIM_VOLUME, 1e-6, 0},
+	"galUS": {DIM_VOLUME, usGallon, 0},
+	"galUK": {DIM_VOLUME, ukGallon, 0},
+
+	// Velocity, in metres per second.
+	"m/s":  {DIM_VELOCITY, 1, 0},
+	"km/h": {DIM_VELOCITY, 1000.0 / 3600, 0},
+	"mph":  {DIM_VELOCITY, 63360 * inch / 3600, 0},
+
+	// Mass, in kilograms.
+	"kg": {DIM_MASS, 1, 0},
+	"g":  {DIM_MASS, 1e-3, 0},
+	"oz": {DIM_MASS, pound / 16, 0},
+	"lb": {DIM_MASS, pound, 0},
+
+	// Force, in newtons.
+	"N":   {DIM_FORCE, 1, 0},
+	"kgf": {DIM_FORCE, gravity, 0},
+
+	// Pressure, in pascals.
+	"Pa":      {DIM_PRESSURE, 1, 0},
+	"kPa":     {DIM_PRESSURE, 1000, 0},
+	"atm":     {DIM_PRESSURE, 101325, 0},
+	"mmHg":    {DIM_PRESSURE, 101325.0 / 760, 0},
+	"kgf/cm2": {DIM_PRESSURE, gravity / 1e-4, 0},
+	"lbf/in2": {DIM_PRESSURE, pound * gravity / (inch * inch), 0},
+
+	// Energy, in joules.
+	"J":    {DIM_ENERGY, 1, 0},
+	"kJ":   {DIM_ENERGY, 1000, 0},
+	"cal":  {DIM_ENERGY, calorie, 0},
+	"kgfm": {DIM_ENERGY, gravity, 0},
+
+	// Power, in watts.
+	"W":  {DIM_POWER, 1, 0},
+	"kW": {DIM_POWER, 1000, 0},
+	"hp": {DIM_POWER, 745.69987158227022, 0},
+
+	// Temperature, in kelvin. The ° symbol starts a DMS literal, so °C and °F are written
+	// as degC and degF.
+	"K":    {DIM_TEMPERATURE, 1, 0},
+	"degC": {DIM_TEMPERATURE, 1, 273.15},
+	"degF": {DIM_TEMPERATURE, 5.0 / 9, 273.15 - 32*5.0/9},
+}
+
+// BuiltinConv converts a value from one unit to another, like the CONV menu on the
+// calculator. The units aren't evaluated, so CONV(5, g, kg) works even if G has been
+// assigned to.
+func BuiltinConv(in object.Interpreter, env *object.Environment, args ...ast.Expression) object.Object {
+	if len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=3", len(args))
+	}
+
+	value, err := evalFloatArgument(in, env, "CONV", 0, args[0])
+	if err != nil {
+		return err
+	}
+
+	fromName, from, err := unitArgument(1, args[1])
+	if err != nil {
+		return err
+	}
+
+	toName, to, err := unitArgument(2, args[2])
+	if err != nil {
+		return err
+	}
+
+	if from.Dimension != to.Dimension {
+		return newError("ArgumentERROR: cannot convert %s (%s) to %s (%s)", fromName, from.Dimension, toName, to.Dimension)
+	}
+
+	si := value*from.Factor + from.Offset
+	result := (si - to.Offset) / to.Factor
+
+	// Offsets are hundreds of kelvin, so rounding error is relative to the temperature
+	// measured from absolute zero rather than to the result. Without this, 212 °F would
+	// convert to 100.00000000000006 °C.
+	if from.Offset != 0 || to.Offset != 0 {
+		result = roundToMagnitude(result, si/to.Factor, AFFINE_FIGURES)
+	} else {
+		result = object.RoundSignificant(result, CONV_FIGURES)
+	}
+
+	return floatResult(result)
+}
+
+// roundToMagnitude rounds a value to the same decimal place as a number of significant
+// figures of magnitude.
+func roundToMagnitude(value, magnitude float64, figures int) float64 {
+	if magnitude == 0 || math.IsNaN(magnitude) || math.IsInf(magnitude, 0) {
+		return value
+	}
+
+	places := figures - 1 - int(math.Floor(math.Log10(math.Abs(magnitude))))
+	scale := math.Pow10(places)
+
+	return math.Round(value*scale) / scale
+}
+
+// unitArgument looks up the unit given as an argument to CONV.
+func unitArgument(i int, arg ast.Expression) (string, Unit, *object.Error) {
+	name := unitName(arg)
+
+	unit, ok := Units[name]
+	if !ok {
+		return "", Unit{}, newError("ArgumentERROR: argument %d to `CONV` must be a unit, got=%s", i+1, arg.String())
+	}
+
+	return name, unit, nil
+}
+
+// unitName writes out a unit the way it appears in the table, such as km/h, from the
+// expression it was parsed as.
+func unitName(arg ast.Expression) string {
+	switch arg := arg.(type) {
+	case *ast.Identifier:
+		return arg.Value
+	case *ast.InfixExpression:
+		if arg.Operator == "/" {
+			return unitName(arg.Left) + "/" + unitName(arg.Right)
+		}
+	}
+
+	return arg.String()
+}
@@ -0,0 +1,36 @@
+package builtins_test
+
+import "testing"
+
+func TestConv(t *testing.T) {
+	testEval(t, []evalTest{
+		{"CONV(1, in, cm)", "2.54"},
+		{"CONV(1, mile, km)", "1.609344"},
+		{"CONV(1, acre, m2)", "4046.8564224"},
+		{"CONV(2, L, mL)", "2000"},
+		{"CONV(1, lb, kg)", "0.45359237"},
+		{"CONV(1, atm, kPa)", "101.325"},
+
+		{"CONV(36, km/h, m/s)", "10"},
+		{"CONV(10, m/s, km/h)", "36"},
+		{"CONV(1, kgf/cm2, Pa)", "98066.5"},
+
+		{"CONV(100, degC, degF)", "212"},
+		{"CONV(212, degF, degC)", "100"},
+		{"CONV(-40, degC, degF)", "-40"},
+		{"CONV(0, degC, K)", "273.15"},
+		{"CONV(0, K, degF)", "-459.67"},
+
+		{"5 -> g\nCONV(5, g, kg)", "0.005"},
+	})
+}
+
+func TestConvErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"CONV(1, km, kg)", "ArgumentERROR: cannot convert km (length) to kg (mass)"},
+		{"CONV(1, km/h, km)", "ArgumentERROR: cannot convert km/h (velocity) to km (length)"},
+		{"CONV(1, degC, J)", "ArgumentERROR: cannot convert degC (temperature) to J (energy)"},
+		{"CONV(1, furlong, m)", "ArgumentERROR: argument 2 to `CONV` must be a unit, got=furlong"},
+		{"CONV(1, m)", "wrong number of arguments. got=2, want=3"},
+	})
+}
@@ -0,0 +1,125 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// BuiltinDot finds the dot product of two vectors.
+func BuiltinDot(args ...object.Object) object.Object {
+	a, b, err := vectorPair("DOT", args)
+	if err != nil {
+		return err
+	}
+
+	return floatResult(dot(a, b))
+}
+
+// BuiltinCross finds the cross product of two vectors. 2D vectors are treated as lying in
+// the xy-plane, so their cross product is a 3D vector along the z-axis.
+func BuiltinCross(args ...object.Object) object.Object {
+	a, b, err := vectorPair("CROSS", args)
+	if err != nil {
+		return err
+	}
+
+	x, y := extend(a), extend(b)
+
+	return &object.Vector{Values: []float64{
+		x[1]*y[2] - x[2]*y[1],
+		x[2]*y[0] - x[0]*y[2],
+		x[0]*y[1] - x[1]*y[0],
+	}}
+}
+
+// BuiltinAngle finds the angle between two vectors. The result is converted into the
+// angle unit.
+func BuiltinAngle(args ...object.Object) object.Object {
+	a, b, err := vectorPair("ANGLE", args)
+	if err != nil {
+		return err
+	}
+
+	if norm(a) == 0 || norm(b) == 0 {
+		return newError("MathERROR")
+	}
+
+	// Using atan2 rather than acos keeps the angle accurate for nearly parallel vectors.
+	cross := BuiltinCross(a, b).(*object.Vector)
+
+	return floatResult(math.Atan2(norm(cross), dot(a, b)))
+}
+
+// BuiltinUnitV finds the unit vector in the same direction as a vector.
+func BuiltinUnitV(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	v, ok := object.Value(args[0]).(*object.Vector)
+	if !ok {
+		return newError("argument to `UNITV` not supported, got=%s", args[0].Type())
+	}
+
+	length := norm(v)
+	if length == 0 {
+		return newError("MathERROR")
+	}
+
+	unit := &object.Vector{Values: make([]float64, len(v.Values))}
+	for i, x := range v.Values {
+		unit.Values[i] = x / length
+	}
+
+	return unit
+}
+
+// vectorPair checks that a builtin has been given two vectors with the same number of
+// elements.
+func vectorPair(name string, args []object.Object) (*object.Vector, *object.Vector, *object.Error) {
+	if len(args) != 2 {
+		return nil, nil, newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	a, ok := object.Value(args[0]).(*object.Vector)
+	if !ok {
+		return nil, nil, newError("argument 1 to `%s` not supported, got=%s", name, args[0].Type())
+	}
+
+	b, ok := object.Value(args[1]).(*object.Vector)
+	if !ok {
+		return nil, nil, newError("argument 2 to `%s` not supported, got=%s", name, args[1].Type())
+	}
+
+	if len(a.Values) != len(b.Values) {
+		return nil, nil, newError("Dimension ERROR: `%s` needs vectors with the same number of elements, got=%d and %d", name, len(a.Values), len(b.Values))
+	}
+
+	return a, b, nil
+}
+
+// dot finds the dot product of two vectors with the same number of elements.
+func dot(a, b *object.Vector) float64 {
+	sum := 0.0
+	for i := range a.Values {
+		sum += a.Values[i] * b.Values[i]
+	}
+
+	return sum
+}
+
+// norm finds the length of a vector.
+func norm(v *object.Vector) float64 {
+	return math.Sqrt(dot(v, v))
+}
+
+// extend returns the elements of a vector as a 3D vector, adding a zero z component to
+// 2D vectors.
+func extend(v *object.Vector) []float64 {
+	if len(v.Values) == 2 {
+		return []float64{v.Values[0], v.Values[1], 0}
+	}
+
+	return v.Values
+}
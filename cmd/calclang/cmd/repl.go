@@ -47,7 +47,12 @@ var replCmd = &cobra.Command{
 			return
 		}
 
-		env := object.NewEnvironment()
+		env, err := newEnvironment(cmd)
+		if err != nil {
+			fmt.Println(au.Red("Could not create environment:").Bold())
+			fmt.Println(au.Red(err))
+			return
+		}
 
 		if file != "" {
 			bytes, err := os.ReadFile(file)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ollybritton/calclang/object"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +18,13 @@ var rootCmd = &cobra.Command{
     calclang repl
 
     calclang repl lex
-    calclang repl parse`,
+    calclang repl parse
+
+    calclang run --angle rad file.calc
+    calclang run --data data.csv stats.calc
+
+    calclang table "X*X" "2*X+1" --start 0 --end 2 --step 0.5
+    calclang spreadsheet sheet.csv`,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -28,3 +35,39 @@ func Execute() {
 		os.Exit(1)
 	}
 }
+
+func init() {
+	rootCmd.PersistentFlags().StringP("angle", "a", "deg", "angle unit to start in: deg, rad or grad")
+	rootCmd.PersistentFlags().StringArrayP("data", "d", nil, "CSV file to load into lists, one per column")
+}
+
+// newEnvironment creates the environment a program is run in, applying any settings
+// given as flags.
+func newEnvironment(cmd *cobra.Command) (*object.Environment, error) {
+	env := object.NewEnvironment()
+
+	angle, err := cmd.Flags().GetString("angle")
+	if err != nil {
+		return nil, err
+	}
+
+	unit, ok := object.ParseAngleUnit(angle)
+	if !ok {
+		return nil, fmt.Errorf("unknown angle unit %q, expected deg, rad or grad", angle)
+	}
+
+	env.SetAngleUnit(unit)
+
+	files, err := cmd.Flags().GetStringArray("data")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if _, err := env.LoadCSV(file); err != nil {
+			return nil, err
+		}
+	}
+
+	return env, nil
+}
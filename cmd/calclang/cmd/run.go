@@ -42,6 +42,13 @@ For now, it will also print the result of the evaluation.`,
 			str = string(bytes)
 		}
 
+		env, err := newEnvironment(cmd)
+		if err != nil {
+			fmt.Println(au.Bold(au.Red("Could not create environment:")))
+			fmt.Println(au.Red(err))
+			return
+		}
+
 		l := lexer.New(str)
 		p := parser.New(l)
 
@@ -51,7 +58,7 @@ For now, it will also print the result of the evaluation.`,
 			return
 		}
 
-		eval := evaluator.Eval(program, object.NewEnvironment())
+		eval := evaluator.Eval(program, env)
 		if eval == nil {
 			return
 		}
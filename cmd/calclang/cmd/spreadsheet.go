@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	au "github.com/logrusorgru/aurora"
+	"github.com/ollybritton/calclang/repl"
+	"github.com/ollybritton/calclang/spreadsheet"
+	"github.com/spf13/cobra"
+)
+
+// spreadsheetCmd represents the spreadsheet command
+var spreadsheetCmd = &cobra.Command{
+	Use:   "spreadsheet [file.csv]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "spreadsheet opens a grid of cells with formulas, like SPREADSHEET mode",
+	Long: `spreadsheet opens a 5×45 grid of cells with formulas, like SPREADSHEET mode.
+
+If a CSV file is given, the sheet is loaded from it and saved back to it by default. The
+file is created when the sheet is first saved if it doesn't exist yet.
+
+  Example:
+    calclang spreadsheet
+    calclang spreadsheet sheet.csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, err := newEnvironment(cmd)
+		if err != nil {
+			fmt.Println(au.Red("Could not create environment:").Bold())
+			fmt.Println(au.Red(err))
+			return
+		}
+
+		sheet := spreadsheet.New(env)
+		path := ""
+
+		if len(args) == 1 {
+			path = args[0]
+
+			file, err := os.Open(path)
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+			case err != nil:
+				fmt.Println(au.Red("Could not open " + path + ":").Bold())
+				fmt.Println(au.Red(err))
+				return
+			default:
+				sheet, err = spreadsheet.ReadCSV(file, env)
+				file.Close()
+
+				if err != nil {
+					fmt.Println(au.Red("Could not load " + path + ":").Bold())
+					fmt.Println(au.Red(err))
+					return
+				}
+			}
+		}
+
+		repl.NewSpreadsheet(sheet, path).Start()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(spreadsheetCmd)
+}
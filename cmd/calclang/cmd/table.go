@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	au "github.com/logrusorgru/aurora"
+	"github.com/ollybritton/calclang/table"
+	"github.com/spf13/cobra"
+)
+
+// tableCmd represents the table command
+var tableCmd = &cobra.Command{
+	Use:   "table f(X) [g(X)]",
+	Args:  cobra.RangeArgs(1, 2),
+	Short: "table prints a table of values for one or two functions of X",
+	Long: `table prints a table of values for one or two functions of X, like TABLE mode.
+
+The start, end and step can be any expression, such as pi/2.
+
+  Example:
+    calclang table "X*X"
+    calclang table "X*X" "2*X+1" --start 0 --end 2 --step 0.5
+    calclang table "sin(X)" --end 360 --step 30 --format csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, err := newEnvironment(cmd)
+		if err != nil {
+			fmt.Println(au.Red("Could not create environment:").Bold())
+			fmt.Println(au.Red(err))
+			return
+		}
+
+		bounds := []float64{}
+		for _, name := range []string{"start", "end", "step"} {
+			input, err := cmd.Flags().GetString(name)
+			if err != nil {
+				fmt.Println(au.Red("Could not fetch '" + name + "' flag:").Bold())
+				fmt.Println(au.Red(err))
+				return
+			}
+
+			value, err := table.EvalNumber(input, env)
+			if err != nil {
+				fmt.Println(au.Red("Could not evaluate the " + name + ":").Bold())
+				fmt.Println(au.Red(err))
+				return
+			}
+
+			bounds = append(bounds, value)
+		}
+
+		name, err := cmd.Flags().GetString("format")
+		if err != nil {
+			fmt.Println(au.Red("Could not fetch 'format' flag:").Bold())
+			fmt.Println(au.Red(err))
+			return
+		}
+
+		format, ok := table.ParseFormat(name)
+		if !ok {
+			fmt.Println(au.Red(fmt.Sprintf("Unknown format %q, expected text, csv or json.", name)).Bold())
+			return
+		}
+
+		t, err := table.New(args, bounds[0], bounds[1], bounds[2], env)
+		if err != nil {
+			fmt.Println(au.Red("Could not create table:").Bold())
+			fmt.Println(au.Red(err))
+			return
+		}
+
+		if err := t.Write(os.Stdout, format); err != nil {
+			fmt.Println(au.Red("Could not write table:").Bold())
+			fmt.Println(au.Red(err))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tableCmd)
+
+	tableCmd.Flags().String("start", "1", "the first value of X")
+	tableCmd.Flags().String("end", "5", "the last value of X")
+	tableCmd.Flags().String("step", "1", "how much X goes up by in each row")
+	tableCmd.Flags().StringP("format", "o", "text", "output format: text, csv or json")
+}
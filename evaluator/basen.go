@@ -0,0 +1,125 @@
+package evaluator
+
+import (
+	"math"
+
+	"github.com/ollybritton/calclang/object"
+)
+
+// evalBaseNInfixExpression evaluates an infix expression in BASE-N mode, where only
+// integers can be used and every result wraps around to a signed 32-bit value. Division
+// discards the remainder, like the calculator.
+func evalBaseNInfixExpression(left object.Object, operator string, right object.Object) object.Object {
+	a, err := baseNOperand(left)
+	if err != nil {
+		return err
+	}
+
+	b, err := baseNOperand(right)
+	if err != nil {
+		return err
+	}
+
+	switch operator {
+	case "+":
+		return baseNResult(a + b)
+	case "-":
+		return baseNResult(a - b)
+	case "*":
+		return baseNResult(a * b)
+	case "/":
+		if b == 0 {
+			return newError("division error: division by zero")
+		}
+
+		return baseNResult(a / b)
+	}
+
+	if result, ok := evalBitwise(a, operator, b); ok {
+		return baseNResult(result)
+	}
+
+	return newError("unknown operator in BASE-N mode: %s %s %s", left.Type(), operator, right.Type())
+}
+
+// evalBaseNPrefixExpression evaluates a prefix expression in BASE-N mode.
+func evalBaseNPrefixExpression(operator string, right object.Object) object.Object {
+	x, err := baseNOperand(right)
+	if err != nil {
+		return err
+	}
+
+	switch operator {
+	case "-", "neg":
+		return baseNResult(-x)
+	case "not":
+		return baseNResult(^x)
+	default:
+		return newError("unknown operator in BASE-N mode: %s%s", operator, right.Type())
+	}
+}
+
+// evalBitwise applies a bitwise infix operator to two integers. It returns false if the
+// operator isn't bitwise.
+func evalBitwise(a int64, operator string, b int64) (int64, bool) {
+	switch operator {
+	case "and":
+		return a & b, true
+	case "or":
+		return a | b, true
+	case "xor":
+		return a ^ b, true
+	case "xnor":
+		return ^(a ^ b), true
+	default:
+		return 0, false
+	}
+}
+
+// baseNOperand converts an operand in BASE-N mode into an integer. Values can be anything
+// that fits in 32 bits, either signed or unsigned, so that 0xFFFFFFFF is the same as -1.
+func baseNOperand(obj object.Object) (int64, *object.Error) {
+	var value int64
+
+	switch obj := object.Value(obj).(type) {
+	case *object.Integer:
+		value = obj.Value
+	case *object.Float:
+		if obj.Value != math.Trunc(obj.Value) || math.Abs(obj.Value) > math.MaxUint32 {
+			return 0, newError("ArgumentERROR: BASE-N mode only supports integers, got=%s", obj.Inspect())
+		}
+
+		value = int64(obj.Value)
+	case *object.BigInteger:
+		return 0, newError("MathERROR")
+	default:
+		return 0, newError("ArgumentERROR: BASE-N mode only supports integers, got=%s", obj.Type())
+	}
+
+	if value < math.MinInt32 || value > math.MaxUint32 {
+		return 0, newError("MathERROR")
+	}
+
+	return int64(int32(value)), nil
+}
+
+// baseNValue converts a number into a 32-bit integer in BASE-N mode, giving an error if it
+// isn't an integer. Anything other than a number is returned unchanged.
+func baseNValue(obj object.Object) object.Object {
+	switch object.Value(obj).(type) {
+	case *object.Integer, *object.Float, *object.BigInteger:
+		value, err := baseNOperand(obj)
+		if err != nil {
+			return err
+		}
+
+		return baseNResult(value)
+	default:
+		return obj
+	}
+}
+
+// baseNResult wraps the result of a calculation around to a signed 32-bit integer.
+func baseNResult(value int64) object.Object {
+	return &object.Integer{Value: int64(int32(value))}
+}
@@ -8,10 +8,34 @@ import "github.com/ollybritton/calclang/object"
 // Rules:
 // int, float => float & float
 // float, int => float & float
+// int, bigint => bigint & bigint
+// bigint, int => bigint & bigint
+// bigint, float => float & float
+// float, bigint => float & float
+// quotient & remainder => quotient (like the calculator)
+// solution => root
+// complex, int/bigint/float => complex & complex
+// int/bigint/float, complex => complex & complex
 func coerceInfix(left object.Object, operator string, right object.Object) (object.Object, object.Object) {
 	_ = operator
 
+	left, right = object.Value(left), object.Value(right)
+
 	switch {
+	case left.Type() == object.COMPLEX_OBJ && right.Type() != object.COMPLEX_OBJ:
+		if y, ok := object.ToComplex(right); ok {
+			return left, &object.Complex{Value: y}
+		}
+
+		return left, right
+
+	case left.Type() != object.COMPLEX_OBJ && right.Type() == object.COMPLEX_OBJ:
+		if x, ok := object.ToComplex(left); ok {
+			return &object.Complex{Value: x}, right
+		}
+
+		return left, right
+
 	case left.Type() == object.FLOAT_OBJ && right.Type() == object.INTEGER_OBJ:
 		x := left.(*object.Float)
 		y := right.(*object.Integer)
@@ -24,6 +48,30 @@ func coerceInfix(left object.Object, operator string, right object.Object) (obje
 
 		return object.IntegerToFloat(x), y
 
+	case left.Type() == object.BIG_INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
+		x := left.(*object.BigInteger)
+		y := right.(*object.Integer)
+
+		return x, object.IntegerToBigInteger(y)
+
+	case left.Type() == object.INTEGER_OBJ && right.Type() == object.BIG_INTEGER_OBJ:
+		x := left.(*object.Integer)
+		y := right.(*object.BigInteger)
+
+		return object.IntegerToBigInteger(x), y
+
+	case left.Type() == object.BIG_INTEGER_OBJ && right.Type() == object.FLOAT_OBJ:
+		x := left.(*object.BigInteger)
+		y := right.(*object.Float)
+
+		return object.BigIntegerToFloat(x), y
+
+	case left.Type() == object.FLOAT_OBJ && right.Type() == object.BIG_INTEGER_OBJ:
+		x := left.(*object.Float)
+		y := right.(*object.BigInteger)
+
+		return x, object.BigIntegerToFloat(y)
+
 	default:
 		return left, right
 	}
@@ -2,6 +2,8 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +13,13 @@ import (
 	"github.com/ollybritton/calclang/object"
 )
 
+// MAX_CALL_DEPTH is the deepest user-defined functions can call one another before a
+// Stack ERROR is given.
+const MAX_CALL_DEPTH int = 1000
+
+// MEMORY is the name of the independent memory that M+ and M- add to and subtract from.
+const MEMORY = "M"
+
 // Eval evaluates a node, and returns its representation as an object.Object.
 func Eval(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
@@ -22,7 +31,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		} else {
 			for {
 				for _, stmt := range node.Loop.Statements {
-					result = Eval(stmt, env)
+					result = evalStatement(stmt, env)
 					if isError(result) {
 						return result
 					}
@@ -50,7 +59,10 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		}
 
 		if node.Name.Constant {
-			panic("no support for setting constants")
+			err := env.SetConstant(node.Name.Value, val)
+			if isError(err) {
+				return err
+			}
 		} else {
 			err := env.Set(node.Name.Value, val)
 			if isError(err) {
@@ -60,7 +72,15 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 
 		return val
 
+	case *ast.MemoryStatement:
+		return evalMemoryStatement(node, env)
+
 	case *ast.InputAssignment:
+		// Check before prompting, so the user isn't asked for a value that can't be stored.
+		if env.IsConstant(node.Name.Value) {
+			return newError("cannot assign to constant %s", node.Name.Value)
+		}
+
 		var i int64
 		var isInt bool
 		var f float64
@@ -107,24 +127,99 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 
 		return obj
 
+	case *ast.Directive:
+		return evalDirective(node, env)
+
+	case *ast.FunctionDefinition:
+		if isBuiltin(node.Name.Value) {
+			return newError("cannot assign to builtin: %s", node.Name.Value)
+		}
+
+		// Parameters are set in the function's environment, which can't shadow a constant.
+		for _, param := range node.Parameters {
+			if env.IsConstant(param.Value) {
+				return newError("cannot use constant %s as a parameter of %s", param.Value, node.Name.Value)
+			}
+		}
+
+		fn := &object.Function{
+			Name:       node.Name.Value,
+			Parameters: node.Parameters,
+			Body:       node.Body,
+			Env:        env,
+		}
+
+		err := env.Set(node.Name.Value, fn)
+		if isError(err) {
+			return err
+		}
+
+		return fn
+
 	case *ast.SubroutineCall:
 		expression := Eval(node.Subroutine, env)
 		if isError(expression) {
 			return expression
 		}
 
-		args := evalExpressions(node.Arguments, env)
-		if len(args) == 1 && isError(args[0]) {
-			return args[0]
+		var result object.Object
+		if builtin, ok := expression.(*object.Builtin); ok && builtin.Form != nil {
+			result = builtin.Form(interpreter{}, env, node.Arguments...)
+		} else {
+			args := evalExpressions(node.Arguments, env)
+			if len(args) == 1 && isError(args[0]) {
+				return args[0]
+			}
+
+			result = applySubroutine(expression, args, env)
+		}
+
+		// In BASE-N mode, functions like SQRT must still give an integer.
+		if env.Radix() != "" && !isError(result) {
+			return baseNValue(result)
 		}
 
-		return applySubroutine(expression, args)
+		return result
 
 	// Literals
 	case *ast.IntegerLiteral:
+		// In BASE-N mode, literals like 0xFFFFFFFF are read as 32-bit values.
+		if env.Radix() != "" {
+			return baseNValue(&object.Integer{Value: node.Value})
+		}
+
 		return &object.Integer{Value: node.Value}
+	case *ast.BigIntegerLiteral:
+		value := object.NewInteger(new(big.Int).Set(node.Value))
+		if env.Radix() != "" {
+			return baseNValue(value)
+		}
+
+		return value
 	case *ast.FloatLiteral:
+		if env.Radix() != "" {
+			return baseNValue(&object.Float{Value: node.Value})
+		}
+
 		return &object.Float{Value: node.Value}
+	case *ast.DMSLiteral:
+		if env.Radix() != "" {
+			return baseNValue(&object.Float{Value: node.Value()})
+		}
+
+		return &object.Float{Value: node.Value()}
+
+	case *ast.ImaginaryLiteral:
+		return object.NewComplex(complex(0, node.Value))
+
+	case *ast.MatrixLiteral:
+		return evalMatrixLiteral(node, env)
+
+	case *ast.VectorLiteral:
+		return evalVectorLiteral(node, env)
+
+	case *ast.ListLiteral:
+		return evalListLiteral(node, env)
 
 	// Expressions
 	case *ast.PrefixExpression:
@@ -133,6 +228,10 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return right
 		}
 
+		if env.Radix() != "" {
+			return evalBaseNPrefixExpression(node.Operator, right)
+		}
+
 		return evalPrefixExpression(node.Operator, right)
 
 	case *ast.InfixExpression:
@@ -146,9 +245,17 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return right
 		}
 
+		if env.Radix() != "" {
+			return evalBaseNInfixExpression(left, node.Operator, right)
+		}
+
 		return evalInfixExpression(left, node.Operator, right)
 
 	case *ast.Identifier:
+		if env.Radix() != "" {
+			return baseNValue(evalIdentifier(node, env))
+		}
+
 		return evalIdentifier(node, env)
 	}
 
@@ -159,7 +266,7 @@ func evalSection(program *ast.Section, env *object.Environment) object.Object {
 	var result object.Object
 
 	for _, statement := range program.Statements {
-		result = Eval(statement, env)
+		result = evalStatement(statement, env)
 
 		switch result := result.(type) {
 		case *object.ReturnValue:
@@ -172,6 +279,89 @@ func evalSection(program *ast.Section, env *object.Environment) object.Object {
 	return result
 }
 
+// evalStatement evaluates a statement in a section. Like pressing = on the calculator,
+// the result of an expression becomes Ans, as does the value stored by an assignment or
+// by M+ and M-.
+func evalStatement(statement ast.Statement, env *object.Environment) object.Object {
+	result := Eval(statement, env)
+	if result == nil || isError(result) {
+		return result
+	}
+
+	switch statement.(type) {
+	case *ast.ExpressionStatement, *ast.VariableAssignment, *ast.MemoryStatement:
+		env.SetAns(object.Value(result))
+	}
+
+	return result
+}
+
+// evalMemoryStatement adds the value of an expression to the independent memory M, or
+// subtracts it. M counts as 0 if nothing has been stored in it yet.
+func evalMemoryStatement(node *ast.MemoryStatement, env *object.Environment) object.Object {
+	val := env.Ans()
+	if node.Value != nil {
+		val = Eval(node.Value, env)
+		if isError(val) {
+			return val
+		}
+	}
+
+	memory, ok := env.Get(MEMORY)
+	if !ok {
+		memory = &object.Integer{Value: 0}
+	}
+
+	var result object.Object
+	if env.Radix() != "" {
+		result = evalBaseNInfixExpression(memory, node.Operator, val)
+	} else {
+		result = evalInfixExpression(memory, node.Operator, val)
+	}
+
+	if isError(result) {
+		return result
+	}
+
+	if err := env.Set(MEMORY, result); isError(err) {
+		return err
+	}
+
+	return val
+}
+
+func evalDirective(node *ast.Directive, env *object.Environment) object.Object {
+	if unit, ok := object.ParseAngleUnit(node.Name); ok {
+		env.SetAngleUnit(unit)
+		return nil
+	}
+
+	if radix, ok := object.ParseRadix(node.Name); ok {
+		env.SetRadix(radix)
+		return nil
+	}
+
+	if format, ok := object.ParseComplexFormat(node.Name); ok {
+		env.SetComplexFormat(format)
+		return nil
+	}
+
+	switch strings.ToUpper(node.Name) {
+	case "CMPLX":
+		env.SetComplexMode(true)
+		return nil
+	case "REAL":
+		env.SetComplexMode(false)
+		return nil
+	case "COMP":
+		env.SetComplexMode(false)
+		env.SetRadix("")
+		return nil
+	}
+
+	return newError("unknown directive: %s", node.String())
+}
+
 func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Object {
 	var result []object.Object
 
@@ -191,6 +381,8 @@ func evalPrefixExpression(operator string, right object.Object) object.Object {
 	switch operator {
 	case "-":
 		return evalMinusPrefixOperatorExpression(right)
+	case "not", "neg":
+		return evalBitwisePrefixExpression(operator, right)
 	default:
 		return newError("unknown operator: %s%s", operator, right.Type())
 	}
@@ -199,24 +391,74 @@ func evalPrefixExpression(operator string, right object.Object) object.Object {
 func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
 	switch val := right.(type) {
 	case *object.Integer:
+		if val.Value == math.MinInt64 {
+			return object.NewInteger(new(big.Int).Neg(big.NewInt(val.Value)))
+		}
+
 		return &object.Integer{Value: -val.Value}
+	case *object.BigInteger:
+		return object.NewInteger(new(big.Int).Neg(val.Value))
 	case *object.Float:
 		return &object.Float{Value: -val.Value}
+	case *object.Complex:
+		return &object.Complex{Value: -val.Value}
+	case *object.Matrix:
+		return evalMatrixInfixExpression(&object.Integer{Value: -1}, "*", val)
+	case *object.Vector:
+		return evalVectorInfixExpression(&object.Integer{Value: -1}, "*", val)
+	case *object.List:
+		return evalListInfixExpression(&object.Integer{Value: -1}, "*", val)
+	case *object.QuotientRemainder, *object.Solution:
+		return evalMinusPrefixOperatorExpression(object.Value(val))
 	default:
 		return newError("unknown operator: -%s", right.Type())
 	}
 }
 
+func evalBitwisePrefixExpression(operator string, right object.Object) object.Object {
+	val, ok := object.Value(right).(*object.Integer)
+	if !ok {
+		return newError("unknown operator: %s%s", operator, right.Type())
+	}
+
+	if operator == "not" {
+		return &object.Integer{Value: ^val.Value}
+	}
+
+	return evalMinusPrefixOperatorExpression(val)
+}
+
 func evalInfixExpression(left object.Object, operator string, right object.Object) object.Object {
 	left, right = coerceInfix(left, operator, right)
 
 	switch {
+	case operator == "=":
+		return newError("equations can only be used inside SOLVE, got=%s = %s", left.Inspect(), right.Inspect())
+
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
 		return evalIntegerInfixExpression(left, operator, right)
 
+	case left.Type() == object.BIG_INTEGER_OBJ && right.Type() == object.BIG_INTEGER_OBJ:
+		return evalBigIntegerInfixExpression(left, operator, right)
+
+	case left.Type() == object.LIST_OBJ || right.Type() == object.LIST_OBJ:
+		return evalListInfixExpression(left, operator, right)
+
+	case left.Type() == object.VECTOR_OBJ || right.Type() == object.VECTOR_OBJ:
+		return evalVectorInfixExpression(left, operator, right)
+
+	case left.Type() == object.MATRIX_OBJ || right.Type() == object.MATRIX_OBJ:
+		return evalMatrixInfixExpression(left, operator, right)
+
+	case operator == "÷R":
+		return newError("ArgumentERROR: ÷R is only defined for integers, got=%s ÷R %s", left.Type(), right.Type())
+
 	case left.Type() == object.FLOAT_OBJ && right.Type() == object.FLOAT_OBJ:
 		return evalFloatInfixExpression(left, operator, right)
 
+	case left.Type() == object.COMPLEX_OBJ && right.Type() == object.COMPLEX_OBJ:
+		return evalComplexInfixExpression(left, operator, right)
+
 	case left.Type() != right.Type():
 		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
 
@@ -229,26 +471,93 @@ func evalIntegerInfixExpression(left object.Object, operator string, right objec
 	leftInt := left.(*object.Integer)
 	rightInt := right.(*object.Integer)
 
+	a, b := leftInt.Value, rightInt.Value
+
+	switch operator {
+	case "+":
+		if sum := a + b; (a^sum)&(b^sum) >= 0 {
+			return &object.Integer{Value: sum}
+		}
+	case "-":
+		if diff := a - b; (a^b)&(a^diff) >= 0 {
+			return &object.Integer{Value: diff}
+		}
+	case "*":
+		if a == 0 || b == 0 {
+			return &object.Integer{Value: 0}
+		}
+
+		if prod := a * b; prod/b == a && !(a == -1 && b == math.MinInt64) && !(b == -1 && a == math.MinInt64) {
+			return &object.Integer{Value: prod}
+		}
+	case "/":
+		if b == 0 {
+			return newError("division error: division by zero")
+		}
+
+		if a%b != 0 {
+			lf := object.IntegerToFloat(leftInt)
+			rf := object.IntegerToFloat(rightInt)
+
+			return &object.Float{Value: lf.Value / rf.Value}
+		}
+
+		if !(a == math.MinInt64 && b == -1) {
+			return &object.Integer{Value: a / b}
+		}
+	case "÷R":
+	default:
+		if result, ok := evalBitwise(a, operator, b); ok {
+			return &object.Integer{Value: result}
+		}
+
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	// Either the result overflowed or the operator is ÷R, so the calculation is done
+	// using big integers.
+	return evalBigIntegerInfixExpression(
+		object.IntegerToBigInteger(leftInt), operator, object.IntegerToBigInteger(rightInt),
+	)
+}
+
+func evalBigIntegerInfixExpression(left object.Object, operator string, right object.Object) object.Object {
+	lb := left.(*object.BigInteger).Value
+	rb := right.(*object.BigInteger).Value
+
 	switch operator {
 	case "+":
-		return &object.Integer{Value: leftInt.Value + rightInt.Value}
+		return object.NewInteger(new(big.Int).Add(lb, rb))
 	case "-":
-		return &object.Integer{Value: leftInt.Value - rightInt.Value}
+		return object.NewInteger(new(big.Int).Sub(lb, rb))
 	case "*":
-		return &object.Integer{Value: leftInt.Value * rightInt.Value}
+		return object.NewInteger(new(big.Int).Mul(lb, rb))
 	case "/":
-		if rightInt.Value == 0 {
+		if rb.Sign() == 0 {
 			return newError("division error: division by zero")
 		}
 
-		if leftInt.Value%rightInt.Value == 0 {
-			return &object.Integer{Value: leftInt.Value / rightInt.Value}
+		quo, rem := new(big.Int).QuoRem(lb, rb, new(big.Int))
+		if rem.Sign() == 0 {
+			return object.NewInteger(quo)
 		}
 
-		lf := object.IntegerToFloat(leftInt)
-		rf := object.IntegerToFloat(rightInt)
+		lf := object.BigIntegerToFloat(left.(*object.BigInteger))
+		rf := object.BigIntegerToFloat(right.(*object.BigInteger))
 
 		return &object.Float{Value: lf.Value / rf.Value}
+	case "÷R":
+		if rb.Sign() == 0 {
+			return newError("division error: division by zero")
+		}
+
+		// Like the calculator, ÷R is only defined for natural numbers.
+		if lb.Sign() < 0 || rb.Sign() < 0 {
+			return newError("MathERROR")
+		}
+
+		quo, rem := new(big.Int).QuoRem(lb, rb, new(big.Int))
+		return &object.QuotientRemainder{Quotient: object.NewInteger(quo), Remainder: object.NewInteger(rem)}
 	default:
 		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
@@ -276,6 +585,28 @@ func evalFloatInfixExpression(left object.Object, operator string, right object.
 	}
 }
 
+func evalComplexInfixExpression(left object.Object, operator string, right object.Object) object.Object {
+	lc := left.(*object.Complex)
+	rc := right.(*object.Complex)
+
+	switch operator {
+	case "+":
+		return object.NewComplex(lc.Value + rc.Value)
+	case "-":
+		return object.NewComplex(lc.Value - rc.Value)
+	case "*":
+		return object.NewComplex(lc.Value * rc.Value)
+	case "/":
+		if rc.Value == 0 {
+			return newError("division error: division by zero")
+		}
+
+		return object.NewComplex(lc.Value / rc.Value)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
 func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
 	if val, ok := env.Get(node.Value); ok {
 		return val
@@ -285,16 +616,94 @@ func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object
 		return builtin
 	}
 
+	// i is the imaginary unit, unless it has been used as a variable.
+	if node.Value == "i" {
+		return &object.Complex{Value: 1i}
+	}
+
+	if object.IsScientificConstantName(node.Value) {
+		return newError("unknown scientific constant: %s", node.Value)
+	}
+
 	return newError("identifier not found: " + node.Value)
 }
 
-func applySubroutine(sub object.Object, args []object.Object) object.Object {
+func applySubroutine(sub object.Object, args []object.Object, env *object.Environment) object.Object {
 	switch sub := sub.(type) {
 
 	case *object.Builtin:
-		return sub.Fn(args...)
+		return applyBuiltin(sub, args, env)
+
+	case *object.Function:
+		return applyFunction(sub, args, env)
 
 	default:
 		return newError("not a subroutine, function or builtin: %s", sub.Type())
 	}
 }
+
+// applyFunction calls a user-defined function, binding its arguments to its parameters in
+// a new environment.
+func applyFunction(fn *object.Function, args []object.Object, env *object.Environment) object.Object {
+	if len(args) != len(fn.Parameters) {
+		return newError("wrong number of arguments to %s. got=%d, want=%d", fn.Name, len(args), len(fn.Parameters))
+	}
+
+	if env.Depth() >= MAX_CALL_DEPTH {
+		return newError("Stack ERROR: maximum recursion depth of %d exceeded in %s", MAX_CALL_DEPTH, fn.Name)
+	}
+
+	inner := object.NewFunctionEnvironment(fn.Env, env)
+
+	for i, param := range fn.Parameters {
+		// A constant may have been declared with the same name as a parameter after the
+		// function was defined.
+		if inner.IsConstant(param.Value) {
+			return newError("cannot use constant %s as a parameter of %s", param.Value, fn.Name)
+		}
+
+		err := inner.Set(param.Value, args[i])
+		if isError(err) {
+			return err
+		}
+	}
+
+	return Eval(fn.Body, inner)
+}
+
+// applyBuiltin calls a builtin, giving it access to the environment if it needs it and
+// converting its arguments or result between the angle unit and radians if the builtin
+// works with angles.
+func applyBuiltin(builtin *object.Builtin, args []object.Object, env *object.Environment) object.Object {
+	unit := env.AngleUnit()
+
+	if builtin.AngleArgs {
+		converted := make([]object.Object, len(args))
+
+		for i, arg := range args {
+			switch arg := object.Value(arg).(type) {
+			case *object.Integer:
+				converted[i] = &object.Float{Value: unit.ToRadians(float64(arg.Value))}
+			case *object.Float:
+				converted[i] = &object.Float{Value: unit.ToRadians(arg.Value)}
+			default:
+				converted[i] = arg
+			}
+		}
+
+		args = converted
+	}
+
+	var result object.Object
+	if builtin.EnvFn != nil {
+		result = builtin.EnvFn(env, args...)
+	} else {
+		result = builtin.Fn(args...)
+	}
+
+	if f, ok := result.(*object.Float); ok && builtin.AngleResult {
+		return &object.Float{Value: unit.FromRadians(f.Value)}
+	}
+
+	return result
+}
@@ -0,0 +1,299 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/ollybritton/calclang/evaluator"
+	"github.com/ollybritton/calclang/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoordinates(t *testing.T) {
+	testEval(t, []evalTest{
+		{"POL(3, 4)", "5"},
+		{"POL(3, 4)\nX", "5"},
+		{"POL(1, 1)\nY", "45"},
+		{"%rad\nPOL(0, 1)\nY", "1.5707963267948966"},
+		{"REC(2, 90)\nX", "0"},
+		{"REC(2, 90)\nY", "2"},
+		{"%rad\nREC(2, pi)\nX", "-2"},
+		{"f(A) := POL(A, A)\nf(1)\nY", "45"},
+		{"f(A) := REC(A, 0)\nf(3) + 1\nX", "3"},
+		{"Σ(POL(N, 0), N, 1, 3)\nX", "3"},
+	})
+}
+
+func TestBaseN(t *testing.T) {
+	testEval(t, []evalTest{
+		{"%hex\n0xFFFFFFFF", "0xFFFFFFFF"},
+		{"%hex\n0xFFFFFFFF - -1", "0x0"},
+		{"%hex\n0x7FFFFFFF + 1", "0x80000000"},
+		{"%hex\n0x80000000 - 1", "0x7FFFFFFF"},
+		{"%hex\n0x10000 * 0x10000", "0x0"},
+		{"%dec\n7 / 2", "3"},
+		{"%dec\n-7 / 2", "-3"},
+		{"%bin\n0b1100 and 0b1010", "0b1000"},
+		{"%bin\n0b1100 or 0b1010", "0b1110"},
+		{"%bin\n0b1100 xor 0b1010", "0b110"},
+		{"%bin\n0b1100 xnor 0b1010", "0b11111111111111111111111111111001"},
+		{"%hex\nnot 0", "0xFFFFFFFF"},
+		{"%hex\nneg 1", "0xFFFFFFFF"},
+		{"%dec\n2.0 -> A\nA", "2"},
+		{"%dec\nSQRT(16)", "4"},
+	})
+}
+
+func TestBaseNErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"%hex\nP(1.5)", "ArgumentERROR: BASE-N mode only supports integers, got=1.5"},
+		{"%hex\n1.5 -> A", "ArgumentERROR: BASE-N mode only supports integers, got=1.5"},
+		{"%dec\nSQRT(2)", "ArgumentERROR: BASE-N mode only supports integers, got=1.4142135623730951"},
+		{"1.5 -> A\n%dec\nA + 1", "ArgumentERROR: BASE-N mode only supports integers, got=1.5"},
+		{"%dec\n1 / 0", "division error: division by zero"},
+		{"%hex\n0x100000000", "MathERROR"},
+	})
+}
+
+func TestComplex(t *testing.T) {
+	testEval(t, []evalTest{
+		{"(1+2i)*(3-4i)", "11+2i"},
+		{"(1+2i)/(1-i)", "-0.5+1.5i"},
+		{"(1+i) + 2", "3+i"},
+		{"2.5 - i", "2.5-i"},
+		{"i*i", "-1"},
+		{"2i -> i\ni", "2i"},
+		{"ABS(3+4i)", "5"},
+		{"ARG(1+i)", "45"},
+		{"%rad\nARG(-1)", "3.141592653589793"},
+		{"CONJG(3+4i)", "3-4i"},
+		{"REP(3+4i)", "3"},
+		{"IMP(3+4i)", "4"},
+		{"%cmplx\nSQRT(-4)", "2i"},
+		{"%cmplx\nSQRT(4)", "2"},
+		{"%polar\n1+i", "1.4142135623730951∠45"},
+		{"%polar\n%rect\n1+i", "1+i"},
+	})
+}
+
+func TestComplexErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"SQRT(-4)", "MathERROR"},
+		{"%cmplx\n%real\nSQRT(-4)", "MathERROR"},
+		{"(1+i) / 0", "division error: division by zero"},
+		{"CONJG([1, 2])", "argument to `CONJG` not supported, got=VECTOR"},
+	})
+}
+
+func TestMatrices(t *testing.T) {
+	testEval(t, []evalTest{
+		{"DET([[1, 2], [3, 4]])", "-2"},
+		{"INV([[1, 2], [3, 4]])", "[[-2, 1], [1.5, -0.5]]"},
+		{"TRN([[1, 2, 3], [4, 5, 6]])", "[[1, 4], [2, 5], [3, 6]]"},
+		{"IDENTITY(2)", "[[1, 0], [0, 1]]"},
+		{"[[1, 2], [3, 4]] * [[5, 6], [7, 8]]", "[[19, 22], [43, 50]]"},
+		{"[[1, 2], [3, 4]] * 2", "[[2, 4], [6, 8]]"},
+		{"[[1, 2], [3, 4]] * INV([[1, 2], [3, 4]])", "[[1, 0], [0, 1]]"},
+	})
+}
+
+func TestMatrixErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"DET([[1, 2, 3], [4, 5, 6]])", "Dimension ERROR: DET needs a square matrix, got=2×3"},
+		{"INV([[1, 2, 3], [4, 5, 6]])", "Dimension ERROR: INV needs a square matrix, got=2×3"},
+		{"INV([[1, 2], [2, 4]])", "MathERROR"},
+		{"[[1, 2]] + [[1], [2]]", "Dimension ERROR: cannot apply + to 1×2 and 2×1 matrices"},
+		{"[[1, 2, 3]] * [[1, 2]]", "Dimension ERROR: cannot multiply 1×3 and 1×2 matrices"},
+	})
+}
+
+func TestVectors(t *testing.T) {
+	testEval(t, []evalTest{
+		{"DOT([1, 2, 3], [4, 5, 6])", "32"},
+		{"CROSS([1, 0, 0], [0, 1, 0])", "[0, 0, 1]"},
+		{"CROSS([1, 2], [3, 4])", "[0, 0, -2]"},
+		{"ANGLE([1, 0], [0, 1])", "90"},
+		{"UNITV([3, 4])", "[0.6, 0.8]"},
+		{"[1, 2] + [3, 4]", "[4, 6]"},
+	})
+}
+
+func TestVectorErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"DOT([1, 2], [1, 2, 3])", "Dimension ERROR: `DOT` needs vectors with the same number of elements, got=2 and 3"},
+		{"[1, 2] + [1, 2, 3]", "Dimension ERROR: cannot apply + to vectors with 2 and 3 elements"},
+		{"[1, 2, 3, 4]", "Dimension ERROR: vectors must have 2 or 3 elements, got=4"},
+	})
+}
+
+func TestFunctions(t *testing.T) {
+	testEval(t, []evalTest{
+		{"f(X) := X * 2\nf(4)", "8"},
+		{"f(X, Y) := X + Y\nf(1, 2)", "3"},
+		{"f() := 3\nf()", "3"},
+		{"f(X) := X * 2\nf([1, 2])", "[2, 4]"},
+		{"f(X) := X * 2\ng(X) := f(X) + 1\ng(3)", "7"},
+		{"f(X) := X * 2\nf -> g\ng(4)", "8"},
+		{"f(X) := X * 2\nf", "f(X) := (X * 2)"},
+
+		// Parameters are local to the call.
+		{"f(X) := X\n5 -> X\nf(2)\nX", "5"},
+
+		// Functions see the variables of the environment they were defined in, as they are
+		// when the function is called, rather than those of the caller.
+		{"2 -> A\nf(X) := X + A\n5 -> A\nf(1)", "6"},
+		{"10 -> A\nf(X) := X + A\ng(A) := f(1)\ng(100)", "11"},
+		{"f(N) := Σ(X * N, X, 1, 3)\nf(2)", "12"},
+	})
+}
+
+func TestFunctionErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"f(X) := X * 2\nf(1, 2)", "wrong number of arguments to f. got=2, want=1"},
+		{"f(X, Y) := X + Y\nf(1)", "wrong number of arguments to f. got=1, want=2"},
+		{"f(X) := X + A\nf(1)", "identifier not found: A"},
+		{"f(X) := X\nf(1)(2)", "not a subroutine, function or builtin: INTEGER"},
+		{"f(X) := f(X + 1)\nf(1)", "Stack ERROR: maximum recursion depth of 1000 exceeded in f"},
+		{"f(X) := g(X)\ng(X) := f(X)\nf(1)", "Stack ERROR: maximum recursion depth of 1000 exceeded in f"},
+	})
+}
+
+func TestAnsAndMemory(t *testing.T) {
+	testEval(t, []evalTest{
+		{"Ans", "0"},
+		{"PreAns", "0"},
+		{"1 + 1\nAns", "2"},
+		{"1\n2\nPreAns", "1"},
+		{"2\nAns * 3\nAns * PreAns", "12"},
+		{"3 -> A\nAns", "3"},
+		{"f(X) := X * 2\n4\nf(Ans)", "8"},
+		{"f(X) := X + 1\n10\nf(1)\nAns", "2"},
+
+		{"3 M+\n4 M+\nM", "7"},
+		{"5 M+\n2 M-\nM", "3"},
+		{"2 M-\nM", "-2"},
+		{"5 M+\nAns", "5"},
+		{"3 M-", "3"},
+		{"3 M+ # add three\nM", "3"},
+		{"3 M+ # add three\n4 M- # take four\nM", "-1"},
+		{"5\nM+\nM", "5"},
+		{"5\nM+\nM+\nM", "10"},
+		{"5\nM-\nM", "-5"},
+		{"2 * 3\nM+ # add Ans\nM", "6"},
+		{"M+\nM", "0"},
+	})
+}
+
+// Each line is evaluated separately in the same environment, like entering them one at a
+// time in the REPL.
+func TestAnsBetweenEvaluations(t *testing.T) {
+	env := object.NewEnvironment()
+
+	for _, input := range []string{"6", "Ans / 2", "Ans + PreAns"} {
+		_, errs := evaluator.EvalString(input, env)
+		assert.Empty(t, errs, "unexpected error for input %q", input)
+	}
+
+	assert.Equal(t, "9", env.Ans().Inspect())
+	assert.Equal(t, "3", env.PreAns().Inspect())
+}
+
+func TestAnsErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"1 -> Ans", "cannot assign to Ans, it holds the result of a calculation"},
+		{"1 -> PreAns", "cannot assign to PreAns, it holds the result of a calculation"},
+		{"const 2 -> Ans", "cannot declare constant Ans, it holds the result of a calculation"},
+	})
+}
+
+func TestConstants(t *testing.T) {
+	testEval(t, []evalTest{
+		{"const 17/91 -> FRAC_A\nFRAC_A * 91", "17"},
+		{"pi", "3.141592653589793"},
+		{"const 3 -> pi\npi * 2", "6"},
+		{"const 2 -> e\nf(X) := X * e\nf(5)", "10"},
+		{"const 1 -> C\nf(X) := X + C\nf(1)", "2"},
+	})
+}
+
+func TestConstantErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"const 1 -> C\n2 -> C", "cannot assign to constant C"},
+		{"const 1 -> C\nconst 2 -> C", "constant C has already been declared"},
+		{"1 -> C\nconst 2 -> C", "cannot declare constant C, it is already a variable"},
+		{"3 -> pi", "cannot assign to constant pi, use `const` to override it"},
+		{"const 3 -> pi\n4 -> pi", "cannot assign to constant pi"},
+		{"f(pi) := pi * 2", "cannot use constant pi as a parameter of f"},
+		{"const 1 -> C\nf(X, C) := X + C", "cannot use constant C as a parameter of f"},
+		{"f(C) := C\nconst 1 -> C\nf(2)", "cannot use constant C as a parameter of f"},
+	})
+}
+
+func TestScientificConstants(t *testing.T) {
+	testEval(t, []evalTest{
+		{"@mp", "0.00000000000000000000000000167262192369"},
+		{"@c0", "299792458"},
+		{"@g", "9.80665"},
+		{"@G", "0.000000000066743"},
+		{"@e / @h", "241798924208491.8"},
+		{"f(M) := M * @c0 * @c0\nf(@mp)", "0.00000000015032776159851256"},
+	})
+}
+
+func TestScientificConstantErrors(t *testing.T) {
+	testEvalErrors(t, []evalTest{
+		{"@nope", "unknown scientific constant: @nope"},
+		{"1 -> @mp", "cannot assign to scientific constant @mp"},
+		{"const 1 -> @mp", "cannot declare constant @mp, names starting with @ are scientific constants"},
+		{"f(@mp) := 1", "cannot use constant @mp as a parameter of f"},
+	})
+}
+
+func TestBigIntegerLiterals(t *testing.T) {
+	testEval(t, []evalTest{
+		{"99999999999999999999", "99999999999999999999"},
+		{"99999999999999999999 + 1", "100000000000000000000"},
+		{"99999999999999999999 - 99999999999999999998", "1"},
+		{"10000000T", "10000000000000000000"},
+		{"9223372036854775808", "9223372036854775808"},
+		{"-9223372036854775808", "-9223372036854775808"},
+	})
+
+	testEvalErrors(t, []evalTest{
+		{"%hex\n0x10000000000000000", "MathERROR"},
+	})
+}
+
+// evalTest is an input to evaluate, along with either its expected result or the message
+// of the error it should give.
+type evalTest struct {
+	input    string
+	expected string
+}
+
+// testEval evaluates each input in a new environment, and checks that it gives the expected
+// result, written the way the REPL displays it.
+func testEval(t *testing.T, tests []evalTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		env := object.NewEnvironment()
+
+		result, errs := evaluator.EvalString(tt.input, env)
+		if assert.Empty(t, errs, "unexpected error for input %q", tt.input) {
+			assert.Equal(t, tt.expected, env.Format(result).Inspect(), "wrong result for input %q", tt.input)
+		}
+	}
+}
+
+// testEvalErrors evaluates each input in a new environment, and checks that it gives an
+// error with the expected message.
+func testEvalErrors(t *testing.T, tests []evalTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		_, errs := evaluator.EvalString(tt.input, object.NewEnvironment())
+		if assert.NotEmpty(t, errs, "expected an error for input %q", tt.input) {
+			assert.Equal(t, "ERROR: "+tt.expected, errs[0].Error(), "wrong error for input %q", tt.input)
+		}
+	}
+}
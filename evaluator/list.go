@@ -0,0 +1,85 @@
+package evaluator
+
+import (
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// evalListLiteral evaluates each element of a list literal.
+func evalListLiteral(node *ast.ListLiteral, env *object.Environment) object.Object {
+	l := &object.List{Values: make([]float64, len(node.Elements))}
+
+	for i, el := range node.Elements {
+		val := Eval(el, env)
+		if isError(val) {
+			return val
+		}
+
+		x, ok := matrixScalar(val)
+		if !ok {
+			return newError("list elements must be numbers, got=%s", val.Type())
+		}
+
+		l.Values[i] = x
+	}
+
+	return l
+}
+
+// evalListInfixExpression evaluates an infix expression where at least one side is a
+// list. Arithmetic is done element by element, so two lists must be the same length, and
+// numbers are broadcast across every element of a list.
+func evalListInfixExpression(left object.Object, operator string, right object.Object) object.Object {
+	if !isArithmetic(operator) {
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	ll, leftIsList := left.(*object.List)
+	rl, rightIsList := right.(*object.List)
+
+	var length int
+	var lhs, rhs func(i int) float64
+
+	switch {
+	case leftIsList && rightIsList:
+		if len(ll.Values) != len(rl.Values) {
+			return newError("Dimension ERROR: cannot apply %s to lists with %d and %d elements", operator, len(ll.Values), len(rl.Values))
+		}
+
+		length = len(ll.Values)
+		lhs = func(i int) float64 { return ll.Values[i] }
+		rhs = func(i int) float64 { return rl.Values[i] }
+
+	case leftIsList:
+		y, ok := matrixScalar(right)
+		if !ok {
+			return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+		}
+
+		length = len(ll.Values)
+		lhs = func(i int) float64 { return ll.Values[i] }
+		rhs = func(i int) float64 { return y }
+
+	default:
+		x, ok := matrixScalar(left)
+		if !ok {
+			return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+		}
+
+		length = len(rl.Values)
+		lhs = func(i int) float64 { return x }
+		rhs = func(i int) float64 { return rl.Values[i] }
+	}
+
+	result := &object.List{Values: make([]float64, length)}
+
+	for i := range result.Values {
+		if operator == "/" && rhs(i) == 0 {
+			return newError("division error: division by zero")
+		}
+
+		result.Values[i] = applyFloat(lhs(i), operator, rhs(i))
+	}
+
+	return result
+}
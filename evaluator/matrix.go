@@ -0,0 +1,167 @@
+package evaluator
+
+import (
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// evalMatrixLiteral evaluates each element of a matrix literal. Every row must have the
+// same number of elements, and the matrix can be at most MAX_MATRIX_SIZE in each direction.
+func evalMatrixLiteral(node *ast.MatrixLiteral, env *object.Environment) object.Object {
+	rows := len(node.Rows)
+	cols := len(node.Rows[0])
+
+	if cols == 0 {
+		return newError("Dimension ERROR: matrices must have at least one column")
+	}
+
+	if rows > object.MAX_MATRIX_SIZE || cols > object.MAX_MATRIX_SIZE {
+		return newError("Dimension ERROR: matrices can be at most %d×%d, got=%d×%d", object.MAX_MATRIX_SIZE, object.MAX_MATRIX_SIZE, rows, cols)
+	}
+
+	m := object.NewMatrix(rows, cols)
+
+	for i, row := range node.Rows {
+		if len(row) != cols {
+			return newError("Dimension ERROR: row %d of matrix has %d elements, want=%d", i+1, len(row), cols)
+		}
+
+		for j, el := range row {
+			val := Eval(el, env)
+			if isError(val) {
+				return val
+			}
+
+			switch val := object.Value(val).(type) {
+			case *object.Integer:
+				m.Values[i][j] = float64(val.Value)
+			case *object.Float:
+				m.Values[i][j] = val.Value
+			case *object.BigInteger:
+				m.Values[i][j] = object.BigIntegerToFloat(val).Value
+			default:
+				return newError("matrix elements must be numbers, got=%s", val.Type())
+			}
+		}
+	}
+
+	return m
+}
+
+// evalMatrixInfixExpression evaluates an infix expression where at least one side is a
+// matrix. Matrices can be added, subtracted and multiplied together, and numbers are
+// broadcast across every element of a matrix.
+func evalMatrixInfixExpression(left object.Object, operator string, right object.Object) object.Object {
+	lm, leftIsMatrix := left.(*object.Matrix)
+	rm, rightIsMatrix := right.(*object.Matrix)
+
+	switch {
+	case leftIsMatrix && rightIsMatrix:
+		switch operator {
+		case "+", "-":
+			if lm.Rows() != rm.Rows() || lm.Cols() != rm.Cols() {
+				return newError("Dimension ERROR: cannot apply %s to %s and %s matrices", operator, lm.Dimension(), rm.Dimension())
+			}
+
+			return mapMatrix(lm, func(i, j int, x float64) float64 {
+				return applyFloat(x, operator, rm.Values[i][j])
+			})
+		case "*":
+			return multiplyMatrices(lm, rm)
+		}
+
+	case !isArithmetic(operator):
+		// Only arithmetic operators are broadcast across a matrix.
+
+	case leftIsMatrix:
+		if y, ok := matrixScalar(right); ok {
+			if operator == "/" && y == 0 {
+				return newError("division error: division by zero")
+			}
+
+			return mapMatrix(lm, func(i, j int, x float64) float64 {
+				return applyFloat(x, operator, y)
+			})
+		}
+
+	case rightIsMatrix:
+		if x, ok := matrixScalar(left); ok && operator != "/" {
+			return mapMatrix(rm, func(i, j int, y float64) float64 {
+				return applyFloat(x, operator, y)
+			})
+		}
+	}
+
+	return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+}
+
+// multiplyMatrices finds the matrix product of two matrices. The first matrix must have as
+// many columns as the second has rows.
+func multiplyMatrices(a, b *object.Matrix) object.Object {
+	if a.Cols() != b.Rows() {
+		return newError("Dimension ERROR: cannot multiply %s and %s matrices", a.Dimension(), b.Dimension())
+	}
+
+	product := object.NewMatrix(a.Rows(), b.Cols())
+
+	for i := range product.Values {
+		for j := range product.Values[i] {
+			for k := 0; k < a.Cols(); k++ {
+				product.Values[i][j] += a.Values[i][k] * b.Values[k][j]
+			}
+		}
+	}
+
+	return product
+}
+
+// mapMatrix creates a new matrix by applying a function to every element of a matrix.
+func mapMatrix(m *object.Matrix, fn func(i, j int, x float64) float64) object.Object {
+	result := object.NewMatrix(m.Rows(), m.Cols())
+
+	for i, row := range m.Values {
+		for j, x := range row {
+			result.Values[i][j] = fn(i, j, x)
+		}
+	}
+
+	return result
+}
+
+// isArithmetic returns true if the operator is +, -, * or /.
+func isArithmetic(operator string) bool {
+	switch operator {
+	case "+", "-", "*", "/":
+		return true
+	default:
+		return false
+	}
+}
+
+// applyFloat applies an arithmetic operator to two floats.
+func applyFloat(x float64, operator string, y float64) float64 {
+	switch operator {
+	case "+":
+		return x + y
+	case "-":
+		return x - y
+	case "*":
+		return x * y
+	default:
+		return x / y
+	}
+}
+
+// matrixScalar converts a number that is being broadcast across a matrix into a float.
+func matrixScalar(obj object.Object) (float64, bool) {
+	switch obj := object.Value(obj).(type) {
+	case *object.Integer:
+		return float64(obj.Value), true
+	case *object.Float:
+		return obj.Value, true
+	case *object.BigInteger:
+		return object.BigIntegerToFloat(obj).Value, true
+	default:
+		return 0, false
+	}
+}
@@ -4,10 +4,23 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ollybritton/calclang/ast"
 	"github.com/ollybritton/calclang/builtins"
 	"github.com/ollybritton/calclang/object"
 )
 
+// interpreter implements object.Interpreter, so that special forms can evaluate their
+// arguments.
+type interpreter struct{}
+
+func (interpreter) Eval(node ast.Node, env *object.Environment) object.Object {
+	return Eval(node, env)
+}
+
+func (interpreter) Infix(left object.Object, operator string, right object.Object) object.Object {
+	return evalInfixExpression(left, operator, right)
+}
+
 func newError(message string, args ...interface{}) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(message, args...)}
 }
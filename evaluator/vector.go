@@ -0,0 +1,88 @@
+package evaluator
+
+import (
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/object"
+)
+
+// evalVectorLiteral evaluates each element of a vector literal. Vectors must have 2 or 3
+// elements, like the calculator.
+func evalVectorLiteral(node *ast.VectorLiteral, env *object.Environment) object.Object {
+	if len(node.Elements) < 2 || len(node.Elements) > 3 {
+		return newError("Dimension ERROR: vectors must have 2 or 3 elements, got=%d", len(node.Elements))
+	}
+
+	v := &object.Vector{Values: make([]float64, len(node.Elements))}
+
+	for i, el := range node.Elements {
+		val := Eval(el, env)
+		if isError(val) {
+			return val
+		}
+
+		x, ok := matrixScalar(val)
+		if !ok {
+			return newError("vector elements must be numbers, got=%s", val.Type())
+		}
+
+		v.Values[i] = x
+	}
+
+	return v
+}
+
+// evalVectorInfixExpression evaluates an infix expression where at least one side is a
+// vector. Vectors can be added and subtracted, and multiplied or divided by numbers.
+func evalVectorInfixExpression(left object.Object, operator string, right object.Object) object.Object {
+	lv, leftIsVector := left.(*object.Vector)
+	rv, rightIsVector := right.(*object.Vector)
+
+	switch {
+	case leftIsVector && rightIsVector:
+		if operator != "+" && operator != "-" {
+			break
+		}
+
+		if len(lv.Values) != len(rv.Values) {
+			return newError("Dimension ERROR: cannot apply %s to vectors with %d and %d elements", operator, len(lv.Values), len(rv.Values))
+		}
+
+		return mapVector(lv, func(i int, x float64) float64 {
+			return applyFloat(x, operator, rv.Values[i])
+		})
+
+	case leftIsVector:
+		y, ok := matrixScalar(right)
+		if !ok || (operator != "*" && operator != "/") {
+			break
+		}
+
+		if operator == "/" && y == 0 {
+			return newError("division error: division by zero")
+		}
+
+		return mapVector(lv, func(i int, x float64) float64 {
+			return applyFloat(x, operator, y)
+		})
+
+	case rightIsVector:
+		if x, ok := matrixScalar(left); ok && operator == "*" {
+			return mapVector(rv, func(i int, y float64) float64 {
+				return x * y
+			})
+		}
+	}
+
+	return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+}
+
+// mapVector creates a new vector by applying a function to every element of a vector.
+func mapVector(v *object.Vector, fn func(i int, x float64) float64) object.Object {
+	result := &object.Vector{Values: make([]float64, len(v.Values))}
+
+	for i, x := range v.Values {
+		result.Values[i] = fn(i, x)
+	}
+
+	return result
+}
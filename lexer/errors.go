@@ -0,0 +1,32 @@
+package lexer
+
+import (
+	"fmt"
+
+	"github.com/ollybritton/calclang/token"
+)
+
+// MalformedNumberError represents an error that occurs when the lexer encounters
+// something that starts like a number but cannot be one, such as `1.2.3` or `0b102`.
+type MalformedNumberError struct {
+	Message string
+
+	Tok    token.Token
+	Reason string
+}
+
+func (e MalformedNumberError) Error() string {
+	return e.Message
+}
+
+// NewMalformedNumberError returns a new MalformedNumberError.
+func NewMalformedNumberError(tok token.Token, reason string) MalformedNumberError {
+	msg := fmt.Sprintf("malformed number %q: %s (line=%d, startcol=%d, endcol=%d)", tok.Literal, reason, tok.Line, tok.StartCol, tok.EndCol)
+
+	return MalformedNumberError{
+		Message: msg,
+
+		Tok:    tok,
+		Reason: reason,
+	}
+}
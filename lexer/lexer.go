@@ -1,6 +1,9 @@
 package lexer
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ollybritton/calclang/token"
 )
 
@@ -8,8 +11,9 @@ import (
 // Its job is to translate a series of characters into chunks such as INTEGER(5) or
 // IDENT("tanh"). It also attaches information such as the position inside the input.
 //
-// BUG(me): At the moment, the lexer does not support Unicode, so only ASCII characters
-// are supported.
+// BUG(me): At the moment, the lexer only partially supports Unicode. Non-ASCII
+// characters are allowed inside identifiers and a few literals, but columns are counted
+// in bytes rather than characters.
 type Lexer struct {
 	input string
 
@@ -23,6 +27,7 @@ type Lexer struct {
 
 	ch byte // Current char under examination.
 
+	errors []error // Errors encountered while lexing, such as malformed numbers.
 }
 
 // New returns a new, initialised lexer.
@@ -36,6 +41,16 @@ func New(input string) *Lexer {
 	return l
 }
 
+// Errors returns the errors that occured during lexing.
+func (l *Lexer) Errors() []error {
+	return l.errors
+}
+
+// addError adds an error to the lexer's internal error list.
+func (l *Lexer) addError(err error) {
+	l.errors = append(l.errors, err)
+}
+
 // readChar reads the next character in the input. If there are no characters left to
 // read (i.e the input is finished or the input is blank), then the l.ch value is set
 // to the NUL character.
@@ -71,6 +86,18 @@ func (l *Lexer) doublePeekChar() byte {
 	return l.input[l.readPosition]
 }
 
+// atMemoryKey returns true if the M that has just been read is followed by a + or - at
+// the end of the statement, like the M+ and M- keys. A comment also ends the statement.
+// Otherwise, as in M+1, it is just the variable M.
+func (l *Lexer) atMemoryKey() bool {
+	if l.ch != '+' && l.ch != '-' {
+		return false
+	}
+
+	rest := strings.TrimLeft(l.input[l.readPosition:], " \t\r")
+	return rest == "" || rest[0] == '\n' || rest[0] == ':' || rest[0] == '#'
+}
+
 // skipWhitespace will skip over whitespace. If it encounters a newline, it increments
 // the startLine and resets the startPosition.
 func (l *Lexer) skipWhitespace() {
@@ -80,28 +107,16 @@ func (l *Lexer) skipWhitespace() {
 	}
 }
 
-// skipComment will skip over a comment.
+// skipComment will skip over a comment. The newline at the end of the comment is left
+// alone, as it still ends the statement before the comment.
 func (l *Lexer) skipComment() {
-
 	if l.ch != '#' {
-
 		return
 	}
 
 	for l.ch != '\n' && l.ch != byte(0) {
 		l.readChar()
-
 	}
-
-	l.readChar()
-
-	l.curLine++
-	l.curLinePosition = 0
-
-	if l.ch == '#' {
-		l.skipComment()
-	}
-
 }
 
 // readIdentifier will reads a set of characters (including an underscore) and returns
@@ -111,41 +126,208 @@ func (l *Lexer) readIdentifier() string {
 	l.startPosition = l.curLinePosition
 	start := l.position
 
-	for isValidIdentCharacter(l.ch) || isDigit(l.ch) && (l.curLinePosition != l.startPosition) {
+	for (isValidIdentCharacter(l.ch) || isDigit(l.ch) && (l.curLinePosition != l.startPosition)) && !l.atOperator() {
 		l.readChar()
 	}
 
 	return l.input[start:l.position]
 }
 
-// readNumber reads a set of digits and returns a number as a string.
-// It accepts int, floats.
-func (l *Lexer) readNumber() (string, token.Type) {
+// readNumber reads a numeric literal and returns it as a string, along with its type.
+// It accepts integers, floats, exponent notation entered with the ×10ˣ key (`1.5e-3`,
+// `1.5E+10`), integers with a `0x`, `0b` or `0o` radix prefix, numbers followed by an
+// engineering symbol (`4.7k`), imaginary numbers (`2i`, `1.5e3i`) and
+// degrees-minutes-seconds (`12°34'56"`).
+// If the number is malformed, such as `1.2.3` or `0b102`, the token.ILLEGAL type is
+// returned alongside the reason why.
+func (l *Lexer) readNumber() (string, token.Type, string) {
 	l.startPosition = l.curLinePosition
 	start := l.position
 
-	numtype := "integer"
-	eoi := false
+	var numtype token.Type = token.INT
+	var reason string
+	base := 10
 
-	for !eoi {
-		switch {
-		case isDigit(l.ch):
+	if l.ch == '0' && isRadixPrefix(l.peekChar()) {
+		l.readChar()
+		base = radixBase(l.ch)
+		l.readChar()
+
+		if !isDigitInBase(l.ch, base) {
+			reason = fmt.Sprintf("missing digits after %s prefix", l.input[start:l.position])
+		}
+
+		for isDigitInBase(l.ch, base) {
 			l.readChar()
+		}
+	} else {
+		numtype, reason = l.readDecimal()
+
+		if reason == "" && l.atImaginarySuffix() {
+			l.readChar()
+			numtype = token.IMAG
+		} else if reason == "" && !strings.ContainsAny(l.input[start:l.position], "eE") {
+			if strings.HasPrefix(l.input[l.position:], "°") {
+				numtype = token.DMS
+				reason = l.readDMS()
+			} else {
+				l.readEngineeringSymbol()
+			}
+		}
+	}
+
+	// A number running straight into more letters, digits or points is malformed. The
+	// rest of it is consumed so that it is reported as one token.
+	if reason == "" && l.continuesNumber() {
+		switch {
+		case l.ch == '.' && numtype == token.FLOAT:
+			reason = "too many decimal points"
 		case l.ch == '.':
-			numtype = "float"
+			reason = fmt.Sprintf("unexpected '.' in %s literal", radixName(base))
+		case base != 10:
+			reason = fmt.Sprintf("invalid digit %q in %s literal", l.ch, radixName(base))
+		default:
+			reason = fmt.Sprintf("unexpected character %q", l.ch)
+		}
+	}
+
+	for l.continuesNumber() {
+		l.readChar()
+	}
+
+	if reason != "" {
+		return l.input[start:l.position], token.ILLEGAL, reason
+	}
+
+	return l.input[start:l.position], numtype, ""
+}
+
+// readEngineeringSymbol reads an engineering symbol directly after a number, such as the
+// k in `4.7k`. The symbol must be attached to the number and not followed by any other
+// identifier characters, which is what distinguishes `2M` (two million) from `2 M` or
+// `2MB`.
+func (l *Lexer) readEngineeringSymbol() {
+	rest := l.input[l.position:]
+
+	for symbol := range token.EngineeringSymbols {
+		if !strings.HasPrefix(rest, symbol) {
+			continue
+		}
+
+		if len(rest) > len(symbol) && !startsOperator(rest[len(symbol):]) {
+			next := rest[len(symbol)]
+			if isValidIdentCharacter(next) || isDigit(next) || next == '.' {
+				continue
+			}
+		}
+
+		for i := 0; i < len(symbol); i++ {
+			l.readChar()
+		}
+
+		return
+	}
+}
+
+// atImaginarySuffix returns true if the current character is the i at the end of an
+// imaginary number such as `2i`, rather than the start of an identifier.
+func (l *Lexer) atImaginarySuffix() bool {
+	if l.ch != 'i' {
+		return false
+	}
+
+	rest := l.input[l.position+1:]
+	if rest == "" || startsOperator(rest) {
+		return true
+	}
+
+	return !isValidIdentCharacter(rest[0]) && !isDigit(rest[0]) && rest[0] != '.'
+}
+
+// continuesNumber returns true if the current character would carry on from the end of a
+// number, making it malformed.
+func (l *Lexer) continuesNumber() bool {
+	return (isValidIdentCharacter(l.ch) || isDigit(l.ch) || l.ch == '.') && !l.atOperator()
+}
+
+// atOperator returns true if the lexer is at the start of an operator made of non-ASCII
+// characters, which would otherwise be read as part of an identifier.
+func (l *Lexer) atOperator() bool {
+	return startsOperator(l.input[l.position:])
+}
+
+// readDMS reads the rest of a degrees-minutes-seconds literal such as `12°34'56"`, once
+// the degrees have been read. The minutes and seconds are both optional, so `12°`,
+// `12°34'` and `12°56"` are also valid. It returns the reason the literal is malformed,
+// if it is.
+func (l *Lexer) readDMS() string {
+	for i := 0; i < len("°"); i++ {
+		l.readChar()
+	}
+
+	for _, mark := range []byte{'\'', '"'} {
+		if !isDigit(l.ch) {
+			return ""
+		}
+
+		start := l.position
+		for isDigit(l.ch) || l.ch == '.' {
 			l.readChar()
+		}
+
+		if strings.Count(l.input[start:l.position], ".") > 1 {
+			if l.ch == '\'' || l.ch == '"' {
+				l.readChar()
+			}
+
+			return "too many decimal points"
+		}
+
+		switch l.ch {
+		case mark:
+			l.readChar()
+		case '"':
+			l.readChar()
+			return ""
 		default:
-			eoi = true
+			return fmt.Sprintf("expected %q after %s", mark, l.input[start:l.position])
 		}
 	}
 
-	switch numtype {
-	case "integer", "hexidecimal", "binary":
-		return l.input[start:l.position], token.INT
-	default:
-		return l.input[start:l.position], token.FLOAT
+	return ""
+}
+
+// readDecimal reads the digits, decimal point and exponent of a base 10 number.
+// It returns the type of the number, and the reason the number is malformed if it is.
+func (l *Lexer) readDecimal() (token.Type, string) {
+	var numtype token.Type = token.INT
+
+	for isDigit(l.ch) || l.ch == '.' && numtype == token.INT {
+		if l.ch == '.' {
+			numtype = token.FLOAT
+		}
+
+		l.readChar()
+	}
+
+	if l.ch != 'e' && l.ch != 'E' {
+		return numtype, ""
+	}
+
+	l.readChar()
+	if l.ch == '+' || l.ch == '-' {
+		l.readChar()
+	}
+
+	if !isDigit(l.ch) {
+		return token.FLOAT, "missing digits in exponent"
+	}
+
+	for isDigit(l.ch) {
+		l.readChar()
 	}
 
+	return token.FLOAT, ""
 }
 
 // newSingleToken returns a new token from a token type.
@@ -179,6 +361,8 @@ func (l *Lexer) NextToken() token.Token {
 		tok = l.newSingleToken(token.SLASH)
 	case ',':
 		tok = l.newSingleToken(token.COMMA)
+	case '=':
+		tok = l.newSingleToken(token.EQUALS)
 	case ':':
 		if l.peekChar() == ':' && l.doublePeekChar() == ':' {
 			l.readChar()
@@ -191,11 +375,45 @@ func (l *Lexer) NextToken() token.Token {
 				StartCol: l.curLinePosition - 1,
 				EndCol:   l.curLinePosition,
 			}
+		} else if l.peekChar() == '=' {
+			l.readChar()
+
+			tok = token.Token{
+				Type:     token.DEFINE,
+				Literal:  ":=",
+				Line:     l.curLine,
+				StartCol: l.curLinePosition - 1,
+				EndCol:   l.curLinePosition,
+			}
 		} else {
 			tok = l.newSingleToken(token.COLON)
 		}
 	case '?':
 		tok = l.newSingleToken(token.QUESTION_MARK)
+	case '%': // directive, such as %deg
+		if !isValidIdentCharacter(l.peekChar()) {
+			tok = l.newSingleToken(token.ILLEGAL)
+			break
+		}
+
+		startCol := l.curLinePosition
+		start := l.position
+		l.readChar()
+		l.readIdentifier()
+
+		return token.NewToken(token.DIRECTIVE, l.input[start:l.position], l.curLine, startCol, l.curLinePosition-1)
+	case '@': // scientific constant, such as @mp
+		if !isValidIdentCharacter(l.peekChar()) {
+			tok = l.newSingleToken(token.ILLEGAL)
+			break
+		}
+
+		startCol := l.curLinePosition
+		start := l.position
+		l.readChar()
+		l.readIdentifier()
+
+		return token.NewToken(token.IDENT, l.input[start:l.position], l.curLine, startCol, l.curLinePosition-1)
 	case '(':
 		tok = l.newSingleToken(token.LPAREN)
 	case ')':
@@ -204,6 +422,10 @@ func (l *Lexer) NextToken() token.Token {
 		tok = l.newSingleToken(token.LBRACE)
 	case '}':
 		tok = l.newSingleToken(token.RBRACE)
+	case '[':
+		tok = l.newSingleToken(token.LBRACKET)
+	case ']':
+		tok = l.newSingleToken(token.RBRACKET)
 	case '-': // - or ->
 		if l.peekChar() == '>' {
 			prev := l.ch
@@ -240,6 +462,16 @@ func (l *Lexer) NextToken() token.Token {
 
 	// Multiple character handling
 	default:
+		if strings.HasPrefix(l.input[l.position:], token.DIV_REM) {
+			startCol := l.curLinePosition
+
+			for i := 0; i < len(token.DIV_REM); i++ {
+				l.readChar()
+			}
+
+			return token.NewToken(token.DIV_REM, token.DIV_REM, l.curLine, startCol, l.curLinePosition-1)
+		}
+
 		if isValidIdentCharacter(l.ch) {
 			tok.Literal = l.readIdentifier()
 			tok.Type = token.LookupIdent(tok.Literal)
@@ -247,15 +479,30 @@ func (l *Lexer) NextToken() token.Token {
 			tok.StartCol = l.startPosition
 			tok.EndCol = l.curLinePosition - 1
 
+			if tok.Literal == "M" && l.atMemoryKey() {
+				tok.Type = token.M_PLUS
+				if l.ch == '-' {
+					tok.Type = token.M_MINUS
+				}
+
+				tok.Literal += string(l.ch)
+				tok.EndCol = l.curLinePosition
+				l.readChar()
+			}
+
 			return tok
 		} else if isDigit(l.ch) {
-			literal, t := l.readNumber()
+			literal, t, reason := l.readNumber()
 			tok.Literal = literal
 			tok.Type = t
 			tok.Line = l.curLine
 			tok.StartCol = l.startPosition
 			tok.EndCol = l.curLinePosition - 1
 
+			if t == token.ILLEGAL {
+				l.addError(NewMalformedNumberError(tok, reason))
+			}
+
 			return tok
 		}
 
@@ -60,3 +60,343 @@ tanh(10*pi)
 
 	assert.Equal(t, byte(0), l.peekChar(), "lexer should have read all input before tests finish, not enough test cases")
 }
+
+func TestNumberLiterals(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{"42", token.INT, "42"},
+		{"3.14", token.FLOAT, "3.14"},
+		{"1.5e-3", token.FLOAT, "1.5e-3"},
+		{"1.5E+10", token.FLOAT, "1.5E+10"},
+		{"2e5", token.FLOAT, "2e5"},
+		{"0x1F", token.INT, "0x1F"},
+		{"0b1011", token.INT, "0b1011"},
+		{"0o17", token.INT, "0o17"},
+		{"1.2.3", token.ILLEGAL, "1.2.3"},
+		{"0b102", token.ILLEGAL, "0b102"},
+		{"0x", token.ILLEGAL, "0x"},
+		{"0x1.5", token.ILLEGAL, "0x1.5"},
+		{"1e", token.ILLEGAL, "1e"},
+		{"1.5e+", token.ILLEGAL, "1.5e+"},
+		{"12ab", token.ILLEGAL, "12ab"},
+		{"4.7k", token.FLOAT, "4.7k"},
+		{"10μ", token.INT, "10μ"},
+		{"2M", token.INT, "2M"},
+		{"3u", token.INT, "3u"},
+		{"2MB", token.ILLEGAL, "2MB"},
+		{"1e3k", token.ILLEGAL, "1e3k"},
+		{`12°34'56"`, token.DMS, `12°34'56"`},
+		{`12°34'`, token.DMS, `12°34'`},
+		{`12°30.5"`, token.DMS, `12°30.5"`},
+		{`12°`, token.DMS, `12°`},
+		{`12°34`, token.ILLEGAL, `12°34`},
+		{`12°34'5.6.7"`, token.ILLEGAL, `12°34'5.6.7"`},
+		{"2i", token.IMAG, "2i"},
+		{"1.5e3i", token.IMAG, "1.5e3i"},
+		{"2in", token.ILLEGAL, "2in"},
+		{"0x2i", token.ILLEGAL, "0x2i"},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.expectedType, tok.Type, "token type wrong for input %q", tt.input)
+		assert.Equal(t, tt.expectedLiteral, tok.Literal, "token literal wrong for input %q", tt.input)
+		assert.Equal(t, token.Type(token.EOF), l.NextToken().Type, "expected EOF after input %q", tt.input)
+
+		if tt.expectedType == token.ILLEGAL {
+			if assert.Len(t, l.Errors(), 1, "expected one lexer error for input %q", tt.input) {
+				assert.IsType(t, MalformedNumberError{}, l.Errors()[0])
+			}
+		} else {
+			assert.Empty(t, l.Errors(), "unexpected lexer errors for input %q", tt.input)
+		}
+	}
+}
+
+func TestEngineeringSymbolsAndRegisters(t *testing.T) {
+	l := New("2M -> M")
+
+	tests := []token.Token{
+		{Type: token.INT, Literal: "2M"},
+		{Type: token.ASSIGN_TO, Literal: "->"},
+		{Type: token.IDENT, Literal: "M"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+	}
+}
+
+func TestMemoryKeys(t *testing.T) {
+	l := New("3*4 M+\n2 M- : M+1\nM-")
+
+	tests := []token.Token{
+		{Type: token.INT, Literal: "3"},
+		{Type: token.ASTERISK, Literal: "*"},
+		{Type: token.INT, Literal: "4"},
+		{Type: token.M_PLUS, Literal: "M+", StartCol: 4, EndCol: 5},
+		{Type: token.NEWLINE, Literal: "\n"},
+		{Type: token.INT, Literal: "2"},
+		{Type: token.M_MINUS, Literal: "M-", StartCol: 2, EndCol: 3},
+		{Type: token.COLON, Literal: ":"},
+		{Type: token.IDENT, Literal: "M"},
+		{Type: token.PLUS, Literal: "+"},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.NEWLINE, Literal: "\n"},
+		{Type: token.M_MINUS, Literal: "M-"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+
+		if tt.EndCol != 0 {
+			assert.Equal(t, tt.StartCol, tok.StartCol, "token StartCol number wrong for token %s, expecting %s", tok, tt.String())
+			assert.Equal(t, tt.EndCol, tok.EndCol, "token EndCol number wrong for token %s, expecting %s", tok, tt.String())
+		}
+	}
+}
+
+func TestMemoryKeyComments(t *testing.T) {
+	l := New("3 M+ # add\nM-# subtract\nM+#1\nP(M)")
+
+	tests := []token.Token{
+		{Type: token.INT, Literal: "3"},
+		{Type: token.M_PLUS, Literal: "M+"},
+		{Type: token.NEWLINE, Literal: "\n"},
+		{Type: token.M_MINUS, Literal: "M-"},
+		{Type: token.NEWLINE, Literal: "\n"},
+		{Type: token.M_PLUS, Literal: "M+"},
+		{Type: token.NEWLINE, Literal: "\n"},
+		{Type: token.IDENT, Literal: "P"},
+		{Type: token.LPAREN, Literal: "("},
+		{Type: token.IDENT, Literal: "M"},
+		{Type: token.RPAREN, Literal: ")"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+	}
+}
+
+// A comment ends the statement before it, but not the line.
+func TestCommentKeepsNewline(t *testing.T) {
+	l := New("3 # three\n# a whole line\n4")
+
+	tests := []token.Type{token.INT, token.NEWLINE, token.NEWLINE, token.INT, token.EOF}
+
+	for _, expected := range tests {
+		tok := l.NextToken()
+		assert.Equal(t, expected, tok.Type, "token type wrong for token %s", tok)
+	}
+}
+
+func TestDirective(t *testing.T) {
+	l := New("%deg\nSIN(90)")
+
+	tests := []token.Token{
+		{Type: token.DIRECTIVE, Literal: "%deg", Line: 0, StartCol: 0, EndCol: 3},
+		{Type: token.NEWLINE, Literal: "\n"},
+		{Type: token.IDENT, Literal: "SIN"},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+
+		if tt.EndCol != 0 {
+			assert.Equal(t, tt.EndCol, tok.EndCol, "token EndCol number wrong for token %s, expecting %s", tok, tt.String())
+		}
+	}
+}
+
+func TestDivisionWithRemainder(t *testing.T) {
+	l := New("17÷R5")
+
+	tests := []token.Token{
+		{Type: token.INT, Literal: "17"},
+		{Type: token.DIV_REM, Literal: "÷R", StartCol: 2},
+		{Type: token.INT, Literal: "5"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+
+		if tt.StartCol != 0 {
+			assert.Equal(t, tt.StartCol, tok.StartCol, "token StartCol number wrong for token %s, expecting %s", tok, tt.String())
+		}
+	}
+}
+
+func TestUnicodeIdentifiers(t *testing.T) {
+	l := New("Σ(θ, θ, 1, 10μ)")
+
+	tests := []token.Token{
+		{Type: token.IDENT, Literal: "Σ"},
+		{Type: token.LPAREN, Literal: "("},
+		{Type: token.IDENT, Literal: "θ"},
+		{Type: token.COMMA, Literal: ","},
+		{Type: token.IDENT, Literal: "θ"},
+		{Type: token.COMMA, Literal: ","},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.COMMA, Literal: ","},
+		{Type: token.INT, Literal: "10μ"},
+		{Type: token.RPAREN, Literal: ")"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+	}
+}
+
+func TestBrackets(t *testing.T) {
+	l := New("[[1], [2]]")
+
+	tests := []token.Token{
+		{Type: token.LBRACKET, Literal: "["},
+		{Type: token.LBRACKET, Literal: "["},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.RBRACKET, Literal: "]"},
+		{Type: token.COMMA, Literal: ","},
+		{Type: token.LBRACKET, Literal: "["},
+		{Type: token.INT, Literal: "2"},
+		{Type: token.RBRACKET, Literal: "]"},
+		{Type: token.RBRACKET, Literal: "]"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+	}
+}
+
+func TestDefine(t *testing.T) {
+	l := New("f(X) := X:1")
+
+	tests := []token.Token{
+		{Type: token.IDENT, Literal: "f"},
+		{Type: token.LPAREN, Literal: "("},
+		{Type: token.IDENT, Literal: "X"},
+		{Type: token.RPAREN, Literal: ")"},
+		{Type: token.DEFINE, Literal: ":=", StartCol: 5},
+		{Type: token.IDENT, Literal: "X"},
+		{Type: token.COLON, Literal: ":"},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+
+		if tt.StartCol != 0 {
+			assert.Equal(t, tt.StartCol, tok.StartCol, "token StartCol number wrong for token %s, expecting %s", tok, tt.String())
+		}
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	l := New("const 5 -> CONST_A\nCONST 6 -> Const")
+
+	tests := []token.Token{
+		{Type: token.CONST, Literal: "const"},
+		{Type: token.INT, Literal: "5"},
+		{Type: token.ASSIGN_TO, Literal: "->"},
+		{Type: token.IDENT, Literal: "CONST_A"},
+		{Type: token.NEWLINE, Literal: "\n"},
+		{Type: token.CONST, Literal: "CONST"},
+		{Type: token.INT, Literal: "6"},
+		{Type: token.ASSIGN_TO, Literal: "->"},
+		{Type: token.IDENT, Literal: "Const"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+	}
+}
+
+func TestScientificConstants(t *testing.T) {
+	l := New("@mp*@c0 @ 2")
+
+	tests := []token.Token{
+		{Type: token.IDENT, Literal: "@mp", StartCol: 0, EndCol: 2},
+		{Type: token.ASTERISK, Literal: "*"},
+		{Type: token.IDENT, Literal: "@c0", StartCol: 4, EndCol: 6},
+		{Type: token.ILLEGAL, Literal: "@"},
+		{Type: token.INT, Literal: "2"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+
+		if tt.EndCol != 0 {
+			assert.Equal(t, tt.StartCol, tok.StartCol, "token StartCol number wrong for token %s, expecting %s", tok, tt.String())
+			assert.Equal(t, tt.EndCol, tok.EndCol, "token EndCol number wrong for token %s, expecting %s", tok, tt.String())
+		}
+	}
+}
+
+func TestBitwiseOperators(t *testing.T) {
+	l := New("0xFF and not B XOR neg 0b1 or 1 xnor Andy")
+
+	tests := []token.Token{
+		{Type: token.INT, Literal: "0xFF"},
+		{Type: token.AND, Literal: "and"},
+		{Type: token.NOT, Literal: "not"},
+		{Type: token.IDENT, Literal: "B"},
+		{Type: token.XOR, Literal: "XOR"},
+		{Type: token.NEG, Literal: "neg"},
+		{Type: token.INT, Literal: "0b1"},
+		{Type: token.OR, Literal: "or"},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.XNOR, Literal: "xnor"},
+		{Type: token.IDENT, Literal: "Andy"},
+		{Type: token.EOF, Literal: ""},
+	}
+
+	for _, tt := range tests {
+		tok := l.NextToken()
+
+		assert.Equal(t, tt.Type, tok.Type, "token type wrong for token %s, expecting %s", tok, tt.String())
+		assert.Equal(t, tt.Literal, tok.Literal, "token literal wrong for token %s, expecting %s", tok, tt.String())
+	}
+}
@@ -1,5 +1,11 @@
 package lexer
 
+import (
+	"strings"
+
+	"github.com/ollybritton/calclang/token"
+)
+
 // isLetter returns true if the given character (byte) is a letter.
 func isLetter(ch byte) bool {
 	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z'
@@ -17,6 +23,67 @@ func isWhitespace(ch byte) bool {
 }
 
 // isValidIdentCharacter returns true if the character is valid inside an identifier (a character or an underscore)
+// Any non-ASCII byte is also accepted, so that identifiers such as Σ can be used.
 func isValidIdentCharacter(ch byte) bool {
-	return isLetter(ch) || ch == '_'
+	return isLetter(ch) || ch == '_' || ch >= 0x80
+}
+
+// startsOperator returns true if the input starts with an operator made of non-ASCII
+// characters, such as ÷R.
+func startsOperator(input string) bool {
+	return strings.HasPrefix(input, token.DIV_REM)
+}
+
+// isRadixPrefix returns true if the character can follow a leading 0 to change the base
+// of an integer literal, such as the x in `0x1F`.
+func isRadixPrefix(ch byte) bool {
+	switch ch {
+	case 'x', 'X', 'b', 'B', 'o', 'O':
+		return true
+	}
+
+	return false
+}
+
+// isDigitInBase returns true if the character is a valid digit in the given base. Only
+// bases 2, 8, 10 and 16 are supported.
+func isDigitInBase(ch byte, base int) bool {
+	switch base {
+	case 2:
+		return ch == '0' || ch == '1'
+	case 8:
+		return '0' <= ch && ch <= '7'
+	case 16:
+		return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+	default:
+		return isDigit(ch)
+	}
+}
+
+// radixBase converts a radix prefix character into the base it represents.
+func radixBase(ch byte) int {
+	switch ch {
+	case 'x', 'X':
+		return 16
+	case 'b', 'B':
+		return 2
+	case 'o', 'O':
+		return 8
+	default:
+		return 10
+	}
+}
+
+// radixName returns the human-readable name of a base, for use in error messages.
+func radixName(base int) string {
+	switch base {
+	case 2:
+		return "binary"
+	case 8:
+		return "octal"
+	case 16:
+		return "hexadecimal"
+	default:
+		return "decimal"
+	}
 }
@@ -0,0 +1,63 @@
+package object
+
+import (
+	"math"
+	"strings"
+)
+
+// AngleUnit represents the unit angles are measured in, like the angle unit setting on
+// the calculator.
+type AngleUnit string
+
+// Definition of angle units.
+const (
+	ANGLE_DEG  = "DEG"  // Degrees, 360 to a full turn.
+	ANGLE_RAD  = "RAD"  // Radians, 2π to a full turn.
+	ANGLE_GRAD = "GRAD" // Gradians, 400 to a full turn.
+)
+
+// ParseAngleUnit converts the name of an angle unit, such as "deg" or "RAD", into an
+// AngleUnit. It returns false if the name isn't a known angle unit.
+func ParseAngleUnit(name string) (AngleUnit, bool) {
+	switch strings.ToUpper(name) {
+	case "DEG", "D":
+		return ANGLE_DEG, true
+	case "RAD", "R":
+		return ANGLE_RAD, true
+	case "GRAD", "GRA", "G":
+		return ANGLE_GRAD, true
+	default:
+		return "", false
+	}
+}
+
+// turn returns the size of a full turn in the angle unit.
+func (u AngleUnit) turn() float64 {
+	switch u {
+	case ANGLE_RAD:
+		return 2 * math.Pi
+	case ANGLE_GRAD:
+		return 400
+	default:
+		return 360
+	}
+}
+
+// ToRadians converts an angle in this unit into radians.
+func (u AngleUnit) ToRadians(angle float64) float64 {
+	if u == ANGLE_RAD {
+		return angle
+	}
+
+	return angle / u.turn() * 2 * math.Pi
+}
+
+// FromRadians converts an angle in radians into this unit. The result is rounded to 15
+// significant figures so that, for example, ASIN(1) is exactly 90 degrees.
+func (u AngleUnit) FromRadians(angle float64) float64 {
+	if u == ANGLE_RAD {
+		return angle
+	}
+
+	return RoundSignificant(angle/(2*math.Pi)*u.turn(), 15)
+}
@@ -0,0 +1,65 @@
+package object
+
+import "strings"
+
+// ComplexFormat represents how complex numbers are written, like the complex result
+// setting on the calculator.
+type ComplexFormat string
+
+// Definition of complex formats.
+const (
+	COMPLEX_RECT  = "RECT"  // Rectangular form, such as 3+4i.
+	COMPLEX_POLAR = "POLAR" // Polar form, such as 5∠53.13010235415598.
+)
+
+// ParseComplexFormat converts the name of a complex format, such as "polar", into a
+// ComplexFormat. It returns false if the name isn't a known complex format.
+func ParseComplexFormat(name string) (ComplexFormat, bool) {
+	switch strings.ToUpper(name) {
+	case "RECT", "RECTANGULAR":
+		return COMPLEX_RECT, true
+	case "POLAR":
+		return COMPLEX_POLAR, true
+	default:
+		return "", false
+	}
+}
+
+// NewComplex returns the result of a complex calculation. Results with no imaginary part
+// are returned as a *Float, so that i*i is simply -1.
+func NewComplex(value complex128) Object {
+	if imag(value) == 0 {
+		return &Float{Value: real(value)}
+	}
+
+	return &Complex{Value: value}
+}
+
+// ToComplex converts a number into a complex number. It returns false if the object
+// isn't a number.
+func ToComplex(obj Object) (complex128, bool) {
+	switch obj := Value(obj).(type) {
+	case *Complex:
+		return obj.Value, true
+	case *Float:
+		return complex(obj.Value, 0), true
+	case *Integer:
+		return complex(float64(obj.Value), 0), true
+	case *BigInteger:
+		return complex(BigIntegerToFloat(obj).Value, 0), true
+	default:
+		return 0, false
+	}
+}
+
+// WithComplexFormat returns a copy of a complex number that will be written in the given
+// format when it is inspected, with polar arguments in the given angle unit. Objects that
+// aren't complex numbers are returned unchanged.
+func WithComplexFormat(obj Object, format ComplexFormat, unit AngleUnit) Object {
+	c, ok := obj.(*Complex)
+	if !ok {
+		return obj
+	}
+
+	return &Complex{Value: c.Value, Format: format, Angle: unit}
+}
@@ -0,0 +1,129 @@
+package object
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ollybritton/calclang/token"
+)
+
+// ReadLists reads a CSV file into one list per column. If the first row isn't all numbers,
+// it's a header naming each column. Otherwise, the columns are named List1, List2 and so
+// on, like the calculator. Columns can have different lengths by leaving cells at the end
+// of a column empty.
+func ReadLists(r io.Reader) ([]string, []*List, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("no data")
+	}
+
+	cols := len(records[0])
+	names := make([]string, cols)
+
+	if isHeader(records[0]) {
+		for i, name := range records[0] {
+			name = strings.TrimSpace(name)
+			if !isValidName(name) {
+				return nil, nil, fmt.Errorf("column %d has an invalid name %q", i+1, name)
+			}
+
+			names[i] = name
+		}
+
+		records = records[1:]
+	} else {
+		for i := range names {
+			names[i] = fmt.Sprintf("List%d", i+1)
+		}
+	}
+
+	lists := make([]*List, cols)
+	for i := range lists {
+		lists[i] = &List{Values: []float64{}}
+	}
+
+	for row, record := range records {
+		for col, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+
+			if len(lists[col].Values) != row {
+				return nil, nil, fmt.Errorf("column %s has a gap before row %d", names[col], row+1)
+			}
+
+			value, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("column %s has a value %q which isn't a number", names[col], cell)
+			}
+
+			lists[col].Values = append(lists[col].Values, value)
+		}
+	}
+
+	return names, lists, nil
+}
+
+// LoadCSV reads a CSV file into lists using ReadLists, storing each one as a variable. It
+// returns the names of the variables.
+func (e *Environment) LoadCSV(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	names, lists, err := ReadLists(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for i, name := range names {
+		if result, ok := e.Set(name, lists[i]).(*Error); ok {
+			return nil, fmt.Errorf("%s: %s", path, result.Message)
+		}
+	}
+
+	return names, nil
+}
+
+// isHeader returns true if a row of a CSV file contains anything other than numbers.
+func isHeader(record []string) bool {
+	for _, cell := range record {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(cell), 64); err != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isValidName returns true if a name from a CSV header can be used as a variable.
+func isValidName(name string) bool {
+	if name == "" || IsScientificConstantName(name) || token.IsKeyword(name) {
+		return false
+	}
+
+	for i, r := range name {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
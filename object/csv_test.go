@@ -0,0 +1,29 @@
+package object_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ollybritton/calclang/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadListsHeader(t *testing.T) {
+	names, lists, err := object.ReadLists(strings.NewReader("X, Ys\n1, 2\n3, 4\n"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"X", "Ys"}, names)
+		assert.Equal(t, "{1, 3}", lists[0].Inspect())
+		assert.Equal(t, "{2, 4}", lists[1].Inspect())
+	}
+}
+
+func TestReadListsInvalidNames(t *testing.T) {
+	tests := []string{"@mp", "not", "XOR", "const", "1X"}
+
+	for _, name := range tests {
+		_, _, err := object.ReadLists(strings.NewReader("A, " + name + "\n1, 2\n"))
+		if assert.Error(t, err, "expected an error for name %q", name) {
+			assert.Equal(t, "column 2 has an invalid name \""+name+"\"", err.Error())
+		}
+	}
+}
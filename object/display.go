@@ -0,0 +1,100 @@
+package object
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ollybritton/calclang/token"
+)
+
+// Display represents a way of formatting a number for output, like the display settings
+// on the calculator.
+type Display string
+
+// Definition of displays.
+const (
+	DISPLAY_NORM = "NORM" // Ordinary decimal notation.
+	DISPLAY_ENG  = "ENG"  // Engineering notation, using symbols such as k or μ.
+	DISPLAY_DMS  = "DMS"  // Sexagesimal degrees, minutes and seconds, such as 12°34'56".
+)
+
+// WithDisplay returns a copy of a number that will be formatted using the given display
+// when it is inspected. Objects that aren't numbers are returned unchanged.
+func WithDisplay(obj Object, display Display) Object {
+	switch obj := obj.(type) {
+	case *Integer:
+		return &Float{Value: float64(obj.Value), Display: display}
+	case *Float:
+		return &Float{Value: obj.Value, Display: display}
+	default:
+		return obj
+	}
+}
+
+// FormatEngineering formats a number in engineering notation, where the exponent is always
+// a multiple of three and is written using its engineering symbol, such as `4.7k`.
+// Exponents without a symbol are written in exponent notation, such as `1.5e15`.
+func FormatEngineering(value float64) string {
+	if value == 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	exponent := int(math.Floor(math.Log10(math.Abs(value))/3)) * 3
+	mantissa := RoundSignificant(value/math.Pow10(exponent), 10)
+
+	// Rounding can carry the mantissa over into the next group of three, e.g. 999.99999999999.
+	if math.Abs(mantissa) >= 1000 {
+		exponent += 3
+		mantissa = RoundSignificant(value/math.Pow10(exponent), 10)
+	}
+
+	formatted := strconv.FormatFloat(mantissa, 'f', -1, 64)
+
+	if exponent == 0 {
+		return formatted
+	}
+
+	if symbol := token.EngineeringSymbol(exponent); symbol != "" {
+		return formatted + symbol
+	}
+
+	return formatted + "e" + strconv.Itoa(exponent)
+}
+
+// FormatDMS formats a number of decimal degrees in sexagesimal notation, such as
+// `12°34'56"`. Seconds are shown to two decimal places, and the rounding is done on the
+// total number of hundredths of a second so that 59.999" carries into the minutes rather
+// than being shown as 60". Angles too large to count in hundredths of a second are shown
+// in decimal.
+func FormatDMS(value float64) string {
+	if math.IsInf(value, 0) || math.IsNaN(value) || math.Abs(value)*360000 >= math.MaxInt64 {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	sign := ""
+	if value < 0 {
+		sign = "-"
+	}
+
+	hundredths := int64(math.Round(math.Abs(value) * 360000))
+	if hundredths == 0 {
+		sign = ""
+	}
+
+	degrees := hundredths / 360000
+	minutes := hundredths % 360000 / 6000
+	seconds := float64(hundredths%6000) / 100
+
+	return fmt.Sprintf("%s%d°%d'%s\"", sign, degrees, minutes, strconv.FormatFloat(seconds, 'f', -1, 64))
+}
+
+// RoundSignificant rounds a number to a given number of significant figures.
+func RoundSignificant(value float64, figures int) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(value, 'g', figures, 64), 64)
+	if err != nil {
+		return value
+	}
+
+	return rounded
+}
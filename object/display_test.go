@@ -0,0 +1,68 @@
+package object_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ollybritton/calclang/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatEngineering(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{12, "12"},
+		{123456, "123.456k"},
+		{4700, "4.7k"},
+		{-4700, "-4.7k"},
+		{1000, "1k"},
+		{999.9999999999, "1k"},
+		{0.001, "1m"},
+		{0.000999, "999μ"},
+		{0.0000047, "4.7μ"},
+		{1e-9, "1n"},
+		{1e-12, "1p"},
+		{1e6, "1M"},
+		{1e9, "1G"},
+		{1e12, "1T"},
+		{999.99999999999e12, "1e15"},
+		{1e15, "1e15"},
+		{1.5e15, "1.5e15"},
+		{1e18, "1e18"},
+		{1e21, "1e21"},
+		{1e-15, "1e-15"},
+		{1e-16, "100e-18"},
+		{math.Inf(-1), "-Inf"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, object.FormatEngineering(tt.input), "wrong format for %v", tt.input)
+	}
+}
+
+func TestFormatDMS(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected string
+	}{
+		{12.5, `12°30'0"`},
+		{-12.5, `-12°30'0"`},
+		{12.582222222222223, `12°34'56"`},
+		{12.582225, `12°34'56.01"`},
+		{0.99999999, `1°0'0"`},
+		{59.99999 / 60, `1°0'0"`},
+		{0, `0°0'0"`},
+		{-0.000001, `0°0'0"`},
+		{1e300, "1" + strings.Repeat("0", 300)},
+		{math.Inf(1), "+Inf"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, object.FormatDMS(tt.input), "wrong format for %v", tt.input)
+	}
+}
@@ -9,30 +9,87 @@ import (
 type Environment struct {
 	store     map[string]Object
 	constants map[string]Object
+	declared  map[string]bool // The constants declared by the program, rather than defaults.
 	outer     *Environment
+
+	angle         AngleUnit     // The angle unit, only used by the outermost environment.
+	complex       bool          // Whether CMPLX mode is on, only used by the outermost environment.
+	complexFormat ComplexFormat // How complex results are written, only used by the outermost environment.
+	radix         Radix         // The BASE-N radix, empty outside BASE-N mode. Only used by the outermost environment.
+	depth         int           // The number of user-defined function calls this environment is inside.
+
+	ans    Object // The result of the last calculation in this environment, if there has been one.
+	preAns Object // The result of the calculation before that.
+}
+
+// Definition of the answer registers, which hold the results of the last two calculations.
+const (
+	ANS     = "Ans"
+	PRE_ANS = "PreAns"
+)
+
+// DefaultConstants returns the constants every new environment starts with. Unlike
+// constants declared by a program, these can be overridden once per environment using
+// SetConstant.
+func DefaultConstants() map[string]Object {
+	return map[string]Object{
+		"pi": &Float{Value: math.Pi},
+		"e":  &Float{Value: math.E},
+	}
 }
 
 // NewEnvironment creates a new environment.
 func NewEnvironment() *Environment {
 	s := make(map[string]Object)
-	c := make(map[string]Object)
+	d := make(map[string]bool)
 
-	c["pi"] = &Float{Value: math.Pi}
-	c["e"] = &Float{Value: math.E}
-
-	return &Environment{store: s, constants: c, outer: nil}
+	return &Environment{store: s, constants: DefaultConstants(), declared: d, outer: nil, angle: ANGLE_DEG, complexFormat: COMPLEX_RECT}
 }
 
 // NewEnclosedEnvironment creates a new enclosed environment, extending from a previous.
+// It sees the constants of the outer environment rather than starting with its own.
 func NewEnclosedEnvironment(outer *Environment) *Environment {
-	env := NewEnvironment()
-	env.outer = outer
+	s := make(map[string]Object)
+	c := make(map[string]Object)
+	d := make(map[string]bool)
+
+	return &Environment{store: s, constants: c, declared: d, outer: outer, depth: outer.depth}
+}
+
+// NewFunctionEnvironment creates the environment a user-defined function is called in.
+// It extends from the environment the function was defined in, but is one call deeper
+// than the environment it was called from.
+func NewFunctionEnvironment(definition, caller *Environment) *Environment {
+	env := NewEnclosedEnvironment(definition)
+	env.depth = caller.depth + 1
 
 	return env
 }
 
-// Get gets an object by name.
+// Depth gets the number of user-defined function calls the environment is inside.
+func (e *Environment) Depth() int {
+	return e.depth
+}
+
+// Get gets an object by name. Scientific constants like @mp are looked up in the
+// ScientificConstants table rather than the environment.
 func (e *Environment) Get(name string) (Object, bool) {
+	switch name {
+	case ANS:
+		return e.Ans(), true
+	case PRE_ANS:
+		return e.PreAns(), true
+	}
+
+	if IsScientificConstantName(name) {
+		c, ok := LookupScientificConstant(name)
+		if !ok {
+			return nil, false
+		}
+
+		return &Float{Value: c.Value}, true
+	}
+
 	obj, ok := e.store[name]
 	if ok {
 		// In normal store
@@ -59,15 +116,87 @@ func (e *Environment) Get(name string) (Object, bool) {
 
 // Set sets an object by name.
 func (e *Environment) Set(name string, value Object) Object {
+	if IsScientificConstantName(name) {
+		return &Error{Message: fmt.Sprintf("cannot assign to scientific constant %s", name)}
+	}
 
-	if _, ok := e.constants[name]; ok {
-		return &Error{Message: fmt.Sprintf("cannot assign to constant %s", name)}
+	if name == ANS || name == PRE_ANS {
+		return &Error{Message: fmt.Sprintf("cannot assign to %s, it holds the result of a calculation", name)}
+	}
+
+	if owner := e.constantOwner(name); owner != nil {
+		if owner.declared[name] {
+			return &Error{Message: fmt.Sprintf("cannot assign to constant %s", name)}
+		}
+
+		return &Error{Message: fmt.Sprintf("cannot assign to constant %s, use `const` to override it", name)}
 	}
 
 	e.store[name] = value
 	return value
 }
 
+// SetGlobal sets an object by name in the outermost environment, like the registers on
+// the calculator which are shared by every calculation. It is used by builtins such as POL
+// that store results into variables, even when called inside a user-defined function.
+func (e *Environment) SetGlobal(name string, value Object) Object {
+	if e.outer != nil {
+		return e.outer.SetGlobal(name, value)
+	}
+
+	return e.Set(name, value)
+}
+
+// SetConstant declares a constant by name. Default constants like pi can be overridden,
+// but a constant can't be declared twice or share its name with an existing variable.
+func (e *Environment) SetConstant(name string, value Object) Object {
+	if IsScientificConstantName(name) {
+		return &Error{Message: fmt.Sprintf("cannot declare constant %s, names starting with %s are scientific constants", name, SCIENTIFIC_PREFIX)}
+	}
+
+	if name == ANS || name == PRE_ANS {
+		return &Error{Message: fmt.Sprintf("cannot declare constant %s, it holds the result of a calculation", name)}
+	}
+
+	if e.declared[name] {
+		return &Error{Message: fmt.Sprintf("constant %s has already been declared", name)}
+	}
+
+	if _, ok := e.store[name]; ok {
+		return &Error{Message: fmt.Sprintf("cannot declare constant %s, it is already a variable", name)}
+	}
+
+	e.constants[name] = value
+	e.declared[name] = true
+
+	return value
+}
+
+// IsConstant reports whether a name refers to a constant, either in this environment or
+// an outer one.
+func (e *Environment) IsConstant(name string) bool {
+	if IsScientificConstantName(name) {
+		_, ok := LookupScientificConstant(name)
+		return ok
+	}
+
+	return e.constantOwner(name) != nil
+}
+
+// constantOwner finds the environment a constant belongs to, or nil if there is no
+// constant by that name.
+func (e *Environment) constantOwner(name string) *Environment {
+	if _, ok := e.constants[name]; ok {
+		return e
+	}
+
+	if e.outer != nil {
+		return e.outer.constantOwner(name)
+	}
+
+	return nil
+}
+
 // Keys gets the list of all symbols.
 func (e *Environment) Keys() map[string]bool {
 	symbols := make(map[string]bool)
@@ -82,3 +211,130 @@ func (e *Environment) Keys() map[string]bool {
 
 	return symbols
 }
+
+// Ans gets the result of the last calculation, like the Ans key. It is 0 before the first
+// calculation. An enclosed environment shares Ans with its outer environment until it does
+// a calculation of its own, so that evaluating something like a table in an enclosed
+// environment doesn't change Ans.
+func (e *Environment) Ans() Object {
+	if e.ans != nil {
+		return e.ans
+	}
+
+	if e.outer != nil {
+		return e.outer.Ans()
+	}
+
+	return &Integer{Value: 0}
+}
+
+// PreAns gets the result of the calculation before the last one, like PreAns.
+func (e *Environment) PreAns() Object {
+	if e.ans != nil {
+		return e.preAns
+	}
+
+	if e.outer != nil {
+		return e.outer.PreAns()
+	}
+
+	return &Integer{Value: 0}
+}
+
+// SetAns records the result of a calculation as Ans, moving the previous Ans to PreAns.
+func (e *Environment) SetAns(value Object) {
+	e.preAns = e.Ans()
+	e.ans = value
+}
+
+// AngleUnit gets the angle unit that trigonometric functions use. Enclosed environments
+// share the angle unit of the outermost environment.
+func (e *Environment) AngleUnit() AngleUnit {
+	if e.outer != nil {
+		return e.outer.AngleUnit()
+	}
+
+	return e.angle
+}
+
+// SetAngleUnit sets the angle unit that trigonometric functions use.
+func (e *Environment) SetAngleUnit(unit AngleUnit) {
+	if e.outer != nil {
+		e.outer.SetAngleUnit(unit)
+		return
+	}
+
+	e.angle = unit
+}
+
+// ComplexMode reports whether CMPLX mode is on, in which functions like SQRT give complex
+// results rather than errors. Enclosed environments share the mode of the outermost
+// environment.
+func (e *Environment) ComplexMode() bool {
+	if e.outer != nil {
+		return e.outer.ComplexMode()
+	}
+
+	return e.complex
+}
+
+// SetComplexMode turns CMPLX mode on or off.
+func (e *Environment) SetComplexMode(on bool) {
+	if e.outer != nil {
+		e.outer.SetComplexMode(on)
+		return
+	}
+
+	e.complex = on
+}
+
+// ComplexFormat gets how complex results are written, either rectangular or polar.
+func (e *Environment) ComplexFormat() ComplexFormat {
+	if e.outer != nil {
+		return e.outer.ComplexFormat()
+	}
+
+	return e.complexFormat
+}
+
+// SetComplexFormat sets how complex results are written.
+func (e *Environment) SetComplexFormat(format ComplexFormat) {
+	if e.outer != nil {
+		e.outer.SetComplexFormat(format)
+		return
+	}
+
+	e.complexFormat = format
+}
+
+// Radix gets the radix used in BASE-N mode, or an empty Radix if BASE-N mode is off.
+func (e *Environment) Radix() Radix {
+	if e.outer != nil {
+		return e.outer.Radix()
+	}
+
+	return e.radix
+}
+
+// SetRadix turns on BASE-N mode with the given radix, or turns it off if the radix is
+// empty.
+func (e *Environment) SetRadix(radix Radix) {
+	if e.outer != nil {
+		e.outer.SetRadix(radix)
+		return
+	}
+
+	e.radix = radix
+}
+
+// Format prepares a result for output using the environment's settings, writing complex
+// numbers in the complex format and integers in the BASE-N radix.
+func (e *Environment) Format(obj Object) Object {
+	obj = WithComplexFormat(obj, e.ComplexFormat(), e.AngleUnit())
+
+	if radix := e.Radix(); radix != "" {
+		obj = WithRadix(obj, radix)
+	}
+
+	return obj
+}
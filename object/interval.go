@@ -0,0 +1,79 @@
+package object
+
+import (
+	"math"
+	"strings"
+)
+
+// Interval is a range of real numbers, such as -1<x≤3. Either end can be infinite, and an
+// interval whose ends are equal and closed is a single point.
+type Interval struct {
+	Lower, Upper             float64
+	LowerClosed, UpperClosed bool
+}
+
+// IntervalSet represents the solution to an inequality in INEQ mode, as a set of disjoint
+// intervals in increasing order.
+type IntervalSet struct {
+	Intervals []Interval
+}
+
+func (s *IntervalSet) Type() Type { return INTERVAL_SET_OBJ }
+
+// Inspect writes the intervals the way the calculator does, such as "x<-1, 3<x". Sets
+// covering every number, or every number but one, are written specially.
+func (s *IntervalSet) Inspect() string {
+	switch {
+	case len(s.Intervals) == 0:
+		return "No Solution"
+	case len(s.Intervals) == 1 && math.IsInf(s.Intervals[0].Lower, -1) && math.IsInf(s.Intervals[0].Upper, 1):
+		return "All Real Numbers"
+	case len(s.Intervals) == 2 && math.IsInf(s.Intervals[0].Lower, -1) && math.IsInf(s.Intervals[1].Upper, 1) &&
+		s.Intervals[0].Upper == s.Intervals[1].Lower && !s.Intervals[0].UpperClosed && !s.Intervals[1].LowerClosed:
+		return "x≠" + inspectBound(s.Intervals[0].Upper)
+	}
+
+	parts := []string{}
+	for _, interval := range s.Intervals {
+		parts = append(parts, interval.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// String writes an interval as an inequality in x, such as "-1<x≤3" or "x=2".
+func (i Interval) String() string {
+	if i.Lower == i.Upper {
+		return "x=" + inspectBound(i.Lower)
+	}
+
+	var out strings.Builder
+
+	if !math.IsInf(i.Lower, -1) {
+		out.WriteString(inspectBound(i.Lower))
+		out.WriteString(comparison(i.LowerClosed))
+	}
+
+	out.WriteString("x")
+
+	if !math.IsInf(i.Upper, 1) {
+		out.WriteString(comparison(i.UpperClosed))
+		out.WriteString(inspectBound(i.Upper))
+	}
+
+	return out.String()
+}
+
+// comparison returns ≤ for a closed end of an interval and < for an open one.
+func comparison(closed bool) string {
+	if closed {
+		return "≤"
+	}
+
+	return "<"
+}
+
+// inspectBound writes the end of an interval the way it would be displayed.
+func inspectBound(x float64) string {
+	return (&Float{Value: x}).Inspect()
+}
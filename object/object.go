@@ -2,7 +2,13 @@ package object
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
 	"strconv"
+	"strings"
+
+	"github.com/ollybritton/calclang/ast"
 )
 
 // Type represents a type of object, such as an integer or a subroutine.
@@ -11,9 +17,20 @@ type Type string
 // Definition of object types.
 const (
 	INTEGER_OBJ      = "INTEGER"
+	BIG_INTEGER_OBJ  = "BIG_INTEGER"
 	FLOAT_OBJ        = "FLOAT"
+	COMPLEX_OBJ      = "COMPLEX"
+	MATRIX_OBJ       = "MATRIX"
+	VECTOR_OBJ       = "VECTOR"
+	LIST_OBJ         = "LIST"
+	REGRESSION_OBJ   = "REGRESSION"
+	QUOTIENT_REM_OBJ = "QUOTIENT_REMAINDER"
+	SOLUTION_OBJ     = "SOLUTION"
+	EQUATION_OBJ     = "EQUATION_SOLUTION"
+	INTERVAL_SET_OBJ = "INTERVAL_SET"
 	RETURN_VALUE_OBJ = "RETURN_VALUE"
 	BUILTIN_OBJ      = "BUILTIN"
+	FUNCTION_OBJ     = "FUNCTION"
 	ERROR_OBJ        = "ERROR"
 )
 
@@ -27,30 +44,307 @@ type Object interface {
 // program.
 type BuiltinFunction func(args ...Object) Object
 
-// Builtin represents a builtin inside the program.
+// EnvBuiltinFunction represents an external function that also has access to the
+// environment it is called from, so that it can read settings or write to variables.
+type EnvBuiltinFunction func(env *Environment, args ...Object) Object
+
+// Interpreter gives special forms access to the evaluator, without the object package
+// depending on it.
+type Interpreter interface {
+	Eval(node ast.Node, env *Environment) Object             // Eval evaluates a node.
+	Infix(left Object, operator string, right Object) Object // Infix applies an operator such as +.
+}
+
+// SpecialForm represents an external function whose arguments are not evaluated before it
+// is called, such as Σ(X, X, 1, 10), which evaluates its first argument many times.
+type SpecialForm func(in Interpreter, env *Environment, args ...ast.Expression) Object
+
+// Builtin represents a builtin inside the program. Only one of Fn, EnvFn and Form is set.
 type Builtin struct {
 	Fn     BuiltinFunction
+	EnvFn  EnvBuiltinFunction
+	Form   SpecialForm
 	Strict bool
+
+	AngleArgs   bool // The arguments are angles, converted from the angle unit into radians.
+	AngleResult bool // The result is an angle in radians, converted into the angle unit.
 }
 
 func (b *Builtin) Type() Type      { return BUILTIN_OBJ }
 func (b *Builtin) Inspect() string { return "<builtin>" }
 
+// Function represents a user-defined function, such as f(X) := X*X. It remembers the
+// environment it was defined in, so it can use variables from there.
+type Function struct {
+	Name       string
+	Parameters []*ast.Identifier
+	Body       ast.Expression
+	Env        *Environment
+}
+
+func (f *Function) Type() Type { return FUNCTION_OBJ }
+func (f *Function) Inspect() string {
+	params := []string{}
+	for _, p := range f.Parameters {
+		params = append(params, p.String())
+	}
+
+	return fmt.Sprintf("%s(%s) := %s", f.Name, strings.Join(params, ", "), f.Body.String())
+}
+
 // Integer represents an integer within the program.
 type Integer struct {
 	Value int64
+	Radix Radix // The radix the integer is formatted in when inspected, DEC if empty.
+}
+
+func (i *Integer) Type() Type { return INTEGER_OBJ }
+func (i *Integer) Inspect() string {
+	if i.Radix != "" {
+		return FormatRadix(i.Value, i.Radix)
+	}
+
+	return fmt.Sprintf("%d", i.Value)
+}
+
+// BigInteger represents an integer within the program that is too large to fit inside
+// an Integer, such as the result of NCR(100, 50).
+type BigInteger struct {
+	Value *big.Int
 }
 
-func (i *Integer) Type() Type      { return INTEGER_OBJ }
-func (i *Integer) Inspect() string { return fmt.Sprintf("%d", i.Value) }
+func (bi *BigInteger) Type() Type      { return BIG_INTEGER_OBJ }
+func (bi *BigInteger) Inspect() string { return bi.Value.String() }
 
 // Float represents an Float within the program.
 type Float struct {
-	Value float64
+	Value   float64
+	Display Display // How the float is formatted when inspected, NORM if empty.
+	Figures int     // The number of significant figures shown when inspected, or 0 to show all of them.
+}
+
+func (f *Float) Type() Type { return FLOAT_OBJ }
+func (f *Float) Inspect() string {
+	switch f.Display {
+	case DISPLAY_ENG:
+		return FormatEngineering(f.Value)
+	case DISPLAY_DMS:
+		return FormatDMS(f.Value)
+	default:
+		if f.Figures > 0 {
+			return strconv.FormatFloat(RoundSignificant(f.Value, f.Figures), 'f', -1, 64)
+		}
+
+		return strconv.FormatFloat(f.Value, 'f', -1, 64)
+	}
+}
+
+// Complex represents a complex number within the program, such as 3+4i.
+type Complex struct {
+	Value complex128
+
+	Format ComplexFormat // How the number is written when inspected, rectangular by default.
+	Angle  AngleUnit     // The unit the argument is written in when the format is polar.
+}
+
+func (c *Complex) Type() Type { return COMPLEX_OBJ }
+func (c *Complex) Inspect() string {
+	if c.Format == COMPLEX_POLAR {
+		unit := c.Angle
+		if unit == "" {
+			unit = ANGLE_RAD
+		}
+
+		modulus := &Float{Value: cmplx.Abs(c.Value)}
+		argument := &Float{Value: unit.FromRadians(cmplx.Phase(c.Value))}
+
+		return modulus.Inspect() + "∠" + argument.Inspect()
+	}
+
+	re, im := real(c.Value), imag(c.Value)
+
+	imaginary := (&Float{Value: math.Abs(im)}).Inspect() + "i"
+	if math.Abs(im) == 1 {
+		imaginary = "i"
+	}
+
+	switch {
+	case re == 0 && im < 0:
+		return "-" + imaginary
+	case re == 0:
+		return imaginary
+	case im < 0:
+		return (&Float{Value: re}).Inspect() + "-" + imaginary
+	default:
+		return (&Float{Value: re}).Inspect() + "+" + imaginary
+	}
+}
+
+// MAX_MATRIX_SIZE is the largest number of rows or columns a matrix can have, like MatA to
+// MatD on the calculator.
+const MAX_MATRIX_SIZE int = 4
+
+// Matrix represents a matrix within the program, such as [[1, 2], [3, 4]]. Values are
+// stored row by row.
+type Matrix struct {
+	Values [][]float64
+}
+
+func (m *Matrix) Type() Type { return MATRIX_OBJ }
+func (m *Matrix) Inspect() string {
+	rows := []string{}
+
+	for _, row := range m.Values {
+		elements := []string{}
+		for _, v := range row {
+			elements = append(elements, (&Float{Value: v}).Inspect())
+		}
+
+		rows = append(rows, "["+strings.Join(elements, ", ")+"]")
+	}
+
+	return "[" + strings.Join(rows, ", ") + "]"
+}
+
+// Rows gets the number of rows in the matrix.
+func (m *Matrix) Rows() int { return len(m.Values) }
+
+// Cols gets the number of columns in the matrix.
+func (m *Matrix) Cols() int {
+	if len(m.Values) == 0 {
+		return 0
+	}
+
+	return len(m.Values[0])
+}
+
+// Dimension gets the size of the matrix written like the calculator, such as 2×3.
+func (m *Matrix) Dimension() string {
+	return fmt.Sprintf("%d×%d", m.Rows(), m.Cols())
+}
+
+// NewMatrix creates a matrix of zeroes with the given number of rows and columns.
+func NewMatrix(rows, cols int) *Matrix {
+	values := make([][]float64, rows)
+	for i := range values {
+		values[i] = make([]float64, cols)
+	}
+
+	return &Matrix{Values: values}
+}
+
+// Vector represents a 2D or 3D vector within the program, such as [1, 2, 3], like VctA to
+// VctD on the calculator.
+type Vector struct {
+	Values []float64
+}
+
+func (v *Vector) Type() Type { return VECTOR_OBJ }
+func (v *Vector) Inspect() string {
+	elements := []string{}
+	for _, x := range v.Values {
+		elements = append(elements, (&Float{Value: x}).Inspect())
+	}
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// List represents a list of data within the program, such as {1, 2, 3}, like the lists used
+// in STAT mode.
+type List struct {
+	Values  []float64
+	Figures int // The number of significant figures each value is shown to, or 0 to show all of them.
+}
+
+func (l *List) Type() Type { return LIST_OBJ }
+func (l *List) Inspect() string {
+	elements := []string{}
+	for _, x := range l.Values {
+		elements = append(elements, (&Float{Value: x, Figures: l.Figures}).Inspect())
+	}
+
+	return "{" + strings.Join(elements, ", ") + "}"
 }
 
-func (f *Float) Type() Type      { return FLOAT_OBJ }
-func (f *Float) Inspect() string { return strconv.FormatFloat(f.Value, 'f', -1, 64) }
+// RegressionModel is the kind of curve a regression fits to data, written as its equation.
+type RegressionModel string
+
+// Definition of regression models.
+const (
+	LINEAR_REG      RegressionModel = "y=a+bx"
+	QUADRATIC_REG   RegressionModel = "y=a+bx+cx²"
+	LOGARITHMIC_REG RegressionModel = "y=a+b·ln(x)"
+	EXPONENTIAL_REG RegressionModel = "y=a·e^(bx)"
+	POWER_REG       RegressionModel = "y=a·x^b"
+)
+
+// Regression represents the result of fitting a curve to data in STAT mode. Coefficients
+// holds a, b and c in order, and R is the correlation coefficient, which is NaN for models
+// that don't have one or when it is undefined because the y values are all the same.
+type Regression struct {
+	Model        RegressionModel
+	Coefficients []float64
+	R            float64
+}
+
+func (r *Regression) Type() Type { return REGRESSION_OBJ }
+func (r *Regression) Inspect() string {
+	parts := []string{string(r.Model)}
+	for i, x := range r.Coefficients {
+		parts = append(parts, fmt.Sprintf("%c=%s", 'a'+i, (&Float{Value: x}).Inspect()))
+	}
+
+	if !math.IsNaN(r.R) {
+		parts = append(parts, "r="+(&Float{Value: r.R}).Inspect())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// QuotientRemainder represents the result of a ÷R calculation, which has both a quotient
+// and a remainder. When used in another calculation, only the quotient is used, like
+// the calculator.
+type QuotientRemainder struct {
+	Quotient  Object
+	Remainder Object
+}
+
+func (qr *QuotientRemainder) Type() Type { return QUOTIENT_REM_OBJ }
+func (qr *QuotientRemainder) Inspect() string {
+	return fmt.Sprintf("Q=%s, R=%s", qr.Quotient.Inspect(), qr.Remainder.Inspect())
+}
+
+// Solution represents the result of SOLVE, which is the root that was found along with
+// how far apart the left and right hand sides of the equation are there. When used in
+// another calculation, only the root is used.
+type Solution struct {
+	Variable string
+	Root     Object
+	Residual Object
+}
+
+func (s *Solution) Type() Type { return SOLUTION_OBJ }
+func (s *Solution) Inspect() string {
+	return fmt.Sprintf("%s=%s, L-R=%s", s.Variable, s.Root.Inspect(), s.Residual.Inspect())
+}
+
+// EquationSolution represents the result of solving an equation in EQN mode, such as the
+// roots x1 and x2 of a quadratic or the unknowns x and y of a linear system. Each value is
+// either a float or a complex number.
+type EquationSolution struct {
+	Names  []string
+	Values []Object
+}
+
+func (es *EquationSolution) Type() Type { return EQUATION_OBJ }
+func (es *EquationSolution) Inspect() string {
+	parts := []string{}
+	for i, name := range es.Names {
+		parts = append(parts, name+"="+es.Values[i].Inspect())
+	}
+
+	return strings.Join(parts, ", ")
+}
 
 // ReturnValue represents a value that is being returned from a subroutine or from a program as a whole.
 type ReturnValue struct {
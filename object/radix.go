@@ -0,0 +1,94 @@
+package object
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Radix represents the base integers are written in while in BASE-N mode, like the
+// DEC, HEX, BIN and OCT keys on the calculator.
+type Radix string
+
+// Definition of radixes.
+const (
+	RADIX_DEC = "DEC" // Decimal, such as 255.
+	RADIX_HEX = "HEX" // Hexadecimal, such as 0xFF.
+	RADIX_BIN = "BIN" // Binary, such as 0b11111111.
+	RADIX_OCT = "OCT" // Octal, such as 0o377.
+)
+
+// ParseRadix converts the name of a radix, such as "hex", into a Radix. It returns false
+// if the name isn't a known radix.
+func ParseRadix(name string) (Radix, bool) {
+	switch strings.ToUpper(name) {
+	case "DEC":
+		return RADIX_DEC, true
+	case "HEX":
+		return RADIX_HEX, true
+	case "BIN":
+		return RADIX_BIN, true
+	case "OCT":
+		return RADIX_OCT, true
+	default:
+		return "", false
+	}
+}
+
+// Base gets the number base of the radix, such as 16 for HEX.
+func (r Radix) Base() int {
+	switch r {
+	case RADIX_HEX:
+		return 16
+	case RADIX_BIN:
+		return 2
+	case RADIX_OCT:
+		return 8
+	default:
+		return 10
+	}
+}
+
+// prefix gets the prefix integer literals in the radix are written with, such as 0x.
+func (r Radix) prefix() string {
+	switch r {
+	case RADIX_HEX:
+		return "0x"
+	case RADIX_BIN:
+		return "0b"
+	case RADIX_OCT:
+		return "0o"
+	default:
+		return ""
+	}
+}
+
+// FormatRadix formats an integer in a radix, with the same prefix it would be written
+// with in a program. Like BASE-N mode, negative 32-bit values are written using two's
+// complement in every radix but DEC, so -1 is 0xFFFFFFFF.
+func FormatRadix(value int64, radix Radix) string {
+	if radix == RADIX_DEC || radix == "" {
+		return strconv.FormatInt(value, 10)
+	}
+
+	if value < 0 && value >= math.MinInt32 {
+		return radix.prefix() + strings.ToUpper(strconv.FormatUint(uint64(uint32(value)), radix.Base()))
+	}
+
+	if value < 0 {
+		return "-" + radix.prefix() + strings.ToUpper(strconv.FormatUint(uint64(-value), radix.Base()))
+	}
+
+	return radix.prefix() + strings.ToUpper(strconv.FormatInt(value, radix.Base()))
+}
+
+// WithRadix returns a copy of an integer that will be formatted in the given radix when
+// it is inspected. Objects that aren't integers are returned unchanged.
+func WithRadix(obj Object, radix Radix) Object {
+	i, ok := obj.(*Integer)
+	if !ok {
+		return obj
+	}
+
+	return &Integer{Value: i.Value, Radix: radix}
+}
@@ -0,0 +1,111 @@
+package object
+
+import "strings"
+
+// SCIENTIFIC_PREFIX is written before the symbol of a scientific constant, such as `@mp`,
+// so that the constants don't clash with registers and variables.
+const SCIENTIFIC_PREFIX = "@"
+
+// ScientificConstant represents one of the physical constants from the calculator's
+// CONST menu.
+type ScientificConstant struct {
+	Symbol      string  // The symbol, written after SCIENTIFIC_PREFIX, such as "mp".
+	Description string  // What the constant is, such as "proton mass".
+	Category    string  // The section of the CONST menu the constant is in.
+	Value       float64 // The value of the constant, in SI units.
+	Unit        string  // The unit of the value, such as "kg".
+}
+
+// Name gets the name the constant is used by in a program, such as "@mp".
+func (c ScientificConstant) Name() string {
+	return SCIENTIFIC_PREFIX + c.Symbol
+}
+
+// Categories of scientific constants, in the order they appear in the CONST menu.
+const (
+	CONST_UNIVERSAL        = "Universal"
+	CONST_ELECTROMAGNETIC  = "Electromagnetic"
+	CONST_ATOMIC_NUCLEAR   = "Atomic & Nuclear"
+	CONST_PHYSICO_CHEMICAL = "Physico-Chemical"
+	CONST_ADOPTED          = "Adopted Values"
+	CONST_OTHER            = "Other"
+)
+
+// ScientificConstants is the table of the 47 constants in the CONST menu, in menu order.
+// Values are the CODATA 2018 recommended values.
+var ScientificConstants = []ScientificConstant{
+	{"h", "Planck constant", CONST_UNIVERSAL, 6.62607015e-34, "J s"},
+	{"hbar", "reduced Planck constant", CONST_UNIVERSAL, 1.054571817e-34, "J s"},
+	{"c0", "speed of light in vacuum", CONST_UNIVERSAL, 299792458, "m s⁻¹"},
+	{"eps0", "electric constant", CONST_UNIVERSAL, 8.8541878128e-12, "F m⁻¹"},
+	{"mu0", "magnetic constant", CONST_UNIVERSAL, 1.25663706212e-6, "N A⁻²"},
+	{"Z0", "characteristic impedance of vacuum", CONST_UNIVERSAL, 376.730313668, "Ω"},
+	{"G", "Newtonian constant of gravitation", CONST_UNIVERSAL, 6.67430e-11, "m³ kg⁻¹ s⁻²"},
+	{"lP", "Planck length", CONST_UNIVERSAL, 1.616255e-35, "m"},
+	{"tP", "Planck time", CONST_UNIVERSAL, 5.391247e-44, "s"},
+
+	{"muN", "nuclear magneton", CONST_ELECTROMAGNETIC, 5.0507837461e-27, "J T⁻¹"},
+	{"muB", "Bohr magneton", CONST_ELECTROMAGNETIC, 9.2740100783e-24, "J T⁻¹"},
+	{"e", "elementary charge", CONST_ELECTROMAGNETIC, 1.602176634e-19, "C"},
+	{"Phi0", "magnetic flux quantum", CONST_ELECTROMAGNETIC, 2.067833848e-15, "Wb"},
+	{"G0", "conductance quantum", CONST_ELECTROMAGNETIC, 7.748091729e-5, "S"},
+	{"KJ", "Josephson constant", CONST_ELECTROMAGNETIC, 483597.8484e9, "Hz V⁻¹"},
+	{"RK", "von Klitzing constant", CONST_ELECTROMAGNETIC, 25812.80745, "Ω"},
+
+	{"mp", "proton mass", CONST_ATOMIC_NUCLEAR, 1.67262192369e-27, "kg"},
+	{"mn", "neutron mass", CONST_ATOMIC_NUCLEAR, 1.67492749804e-27, "kg"},
+	{"me", "electron mass", CONST_ATOMIC_NUCLEAR, 9.1093837015e-31, "kg"},
+	{"mmu", "muon mass", CONST_ATOMIC_NUCLEAR, 1.883531627e-28, "kg"},
+	{"a0", "Bohr radius", CONST_ATOMIC_NUCLEAR, 5.29177210903e-11, "m"},
+	{"alpha", "fine-structure constant", CONST_ATOMIC_NUCLEAR, 7.2973525693e-3, ""},
+	{"re", "classical electron radius", CONST_ATOMIC_NUCLEAR, 2.8179403262e-15, "m"},
+	{"lambdac", "Compton wavelength", CONST_ATOMIC_NUCLEAR, 2.42631023867e-12, "m"},
+	{"gammap", "proton gyromagnetic ratio", CONST_ATOMIC_NUCLEAR, 2.6752218744e8, "s⁻¹ T⁻¹"},
+	{"lambdacp", "proton Compton wavelength", CONST_ATOMIC_NUCLEAR, 1.32140985539e-15, "m"},
+	{"lambdacn", "neutron Compton wavelength", CONST_ATOMIC_NUCLEAR, 1.31959090581e-15, "m"},
+	{"Rinf", "Rydberg constant", CONST_ATOMIC_NUCLEAR, 10973731.568160, "m⁻¹"},
+	{"mup", "proton magnetic moment", CONST_ATOMIC_NUCLEAR, 1.41060679736e-26, "J T⁻¹"},
+	{"mue", "electron magnetic moment", CONST_ATOMIC_NUCLEAR, -9.2847647043e-24, "J T⁻¹"},
+	{"mun", "neutron magnetic moment", CONST_ATOMIC_NUCLEAR, -9.6623651e-27, "J T⁻¹"},
+	{"mumu", "muon magnetic moment", CONST_ATOMIC_NUCLEAR, -4.49044830e-26, "J T⁻¹"},
+	{"mtau", "tau mass", CONST_ATOMIC_NUCLEAR, 3.16754e-27, "kg"},
+
+	{"u", "atomic mass constant", CONST_PHYSICO_CHEMICAL, 1.66053906660e-27, "kg"},
+	{"F", "Faraday constant", CONST_PHYSICO_CHEMICAL, 96485.33212, "C mol⁻¹"},
+	{"NA", "Avogadro constant", CONST_PHYSICO_CHEMICAL, 6.02214076e23, "mol⁻¹"},
+	{"k", "Boltzmann constant", CONST_PHYSICO_CHEMICAL, 1.380649e-23, "J K⁻¹"},
+	{"Vm", "molar volume of ideal gas (273.15 K, 101.325 kPa)", CONST_PHYSICO_CHEMICAL, 22.41396954e-3, "m³ mol⁻¹"},
+	{"R", "molar gas constant", CONST_PHYSICO_CHEMICAL, 8.314462618, "J mol⁻¹ K⁻¹"},
+	{"c1", "first radiation constant", CONST_PHYSICO_CHEMICAL, 3.741771852e-16, "W m²"},
+	{"c2", "second radiation constant", CONST_PHYSICO_CHEMICAL, 1.438776877e-2, "m K"},
+	{"sigma", "Stefan-Boltzmann constant", CONST_PHYSICO_CHEMICAL, 5.670374419e-8, "W m⁻² K⁻⁴"},
+
+	{"g", "standard acceleration of gravity", CONST_ADOPTED, 9.80665, "m s⁻²"},
+	{"atm", "standard atmosphere", CONST_ADOPTED, 101325, "Pa"},
+	{"RK90", "conventional value of von Klitzing constant", CONST_ADOPTED, 25812.807, "Ω"},
+	{"KJ90", "conventional value of Josephson constant", CONST_ADOPTED, 483597.9e9, "Hz V⁻¹"},
+
+	{"t", "Celsius temperature", CONST_OTHER, 273.15, "K"},
+}
+
+var scientificConstantsByName = func() map[string]ScientificConstant {
+	byName := make(map[string]ScientificConstant, len(ScientificConstants))
+
+	for _, c := range ScientificConstants {
+		byName[c.Name()] = c
+	}
+
+	return byName
+}()
+
+// IsScientificConstantName reports whether a name is written like a scientific constant,
+// such as `@mp`, whether or not a constant by that name exists.
+func IsScientificConstantName(name string) bool {
+	return strings.HasPrefix(name, SCIENTIFIC_PREFIX)
+}
+
+// LookupScientificConstant finds a scientific constant by its name, such as "@mp".
+func LookupScientificConstant(name string) (ScientificConstant, bool) {
+	c, ok := scientificConstantsByName[name]
+	return c, ok
+}
@@ -2,6 +2,7 @@ package object
 
 import (
 	"math"
+	"math/big"
 )
 
 // IntegerToFloat converts an integer object to a float object.
@@ -14,3 +15,39 @@ func FloatToInteger(f *Float) *Integer {
 	val := int64(math.Round(f.Value))
 	return &Integer{Value: val}
 }
+
+// IntegerToBigInteger converts an integer object to a big integer object.
+func IntegerToBigInteger(i *Integer) *BigInteger {
+	return &BigInteger{Value: big.NewInt(i.Value)}
+}
+
+// BigIntegerToFloat converts a big integer object to a float object. Integers too large
+// for a float become infinite.
+func BigIntegerToFloat(bi *BigInteger) *Float {
+	val, _ := new(big.Float).SetInt(bi.Value).Float64()
+	return &Float{Value: val}
+}
+
+// NewInteger returns an Integer if the value fits inside one, and a BigInteger
+// otherwise.
+func NewInteger(value *big.Int) Object {
+	if value.IsInt64() {
+		return &Integer{Value: value.Int64()}
+	}
+
+	return &BigInteger{Value: value}
+}
+
+// Value returns the value an object has when it is used in another calculation. Results
+// which show several values, like ÷R or SOLVE, act as their main value; every other
+// object is returned unchanged.
+func Value(obj Object) Object {
+	switch obj := obj.(type) {
+	case *QuotientRemainder:
+		return obj.Quotient
+	case *Solution:
+		return obj.Root
+	default:
+		return obj
+	}
+}
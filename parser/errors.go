@@ -139,3 +139,56 @@ func NewNoPrefixParseFnError(curTok, peekTok token.Token, unknown token.Type) No
 		UnknownType: unknown,
 	}
 }
+
+// InvalidConstantError represents an error that occurs when the `const` keyword isn't
+// followed by an assignment, such as `const 17/91 -> FRAC_A`, or is used in the loop
+// section.
+type InvalidConstantError struct {
+	Message string
+
+	CurTok  token.Token
+	PeekTok token.Token
+}
+
+func (e InvalidConstantError) Error() string {
+	return e.Message
+}
+
+// NewInvalidConstantError returns a new InvalidConstantError.
+func NewInvalidConstantError(curTok, peekTok token.Token, reason string) InvalidConstantError {
+	msg := fmt.Sprintf("invalid constant declaration: %s (line=%d, startcol=%d, endcol=%d)", reason, curTok.Line, curTok.StartCol, curTok.EndCol)
+
+	return InvalidConstantError{
+		Message: msg,
+
+		CurTok:  curTok,
+		PeekTok: peekTok,
+	}
+}
+
+// InvalidDefinitionError represents an error that occurs when the left hand side of a
+// function definition isn't a function name followed by a list of parameters.
+type InvalidDefinitionError struct {
+	Message string
+
+	CurTok     token.Token
+	PeekTok    token.Token
+	Definition string
+}
+
+func (e InvalidDefinitionError) Error() string {
+	return e.Message
+}
+
+// NewInvalidDefinitionError returns a new InvalidDefinitionError.
+func NewInvalidDefinitionError(curTok, peekTok token.Token, definition, reason string) InvalidDefinitionError {
+	msg := fmt.Sprintf("invalid function definition %q: %s (line=%d, startcol=%d, endcol=%d)", definition, reason, curTok.Line, curTok.StartCol, curTok.EndCol)
+
+	return InvalidDefinitionError{
+		Message: msg,
+
+		CurTok:     curTok,
+		PeekTok:    peekTok,
+		Definition: definition,
+	}
+}
@@ -1,7 +1,11 @@
 package parser
 
 import (
+	"fmt"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/ollybritton/calclang/ast"
 	"github.com/ollybritton/calclang/lexer"
@@ -22,6 +26,7 @@ type Parser struct {
 	peekToken token.Token
 
 	errors []error
+	inLoop bool // Whether the parser has reached the loop section, after the :::.
 
 	prefixParseFns map[token.Type]prefixParseFn
 	infixParseFns  map[token.Type]infixParseFn
@@ -35,17 +40,29 @@ func New(l *lexer.Lexer) *Parser {
 		token.IDENT: p.parseIdentifier,
 		token.INT:   p.parseIntegerLiteral,
 		token.FLOAT: p.parseFloatLiteral,
+		token.DMS:   p.parseDMSLiteral,
+		token.IMAG:  p.parseImaginaryLiteral,
 
 		token.MINUS: p.parsePrefixExpression,
+		token.NOT:   p.parsePrefixExpression,
+		token.NEG:   p.parsePrefixExpression,
 
-		token.LPAREN: p.parseGroupedExpression,
+		token.LPAREN:   p.parseGroupedExpression,
+		token.LBRACKET: p.parseBracketLiteral,
+		token.LBRACE:   p.parseListLiteral,
 	}
 
 	p.infixParseFns = map[token.Type]infixParseFn{
+		token.EQUALS:   p.parseInfixExpression,
 		token.PLUS:     p.parseInfixExpression,
 		token.MINUS:    p.parseInfixExpression,
 		token.SLASH:    p.parseInfixExpression,
 		token.ASTERISK: p.parseInfixExpression,
+		token.DIV_REM:  p.parseInfixExpression,
+		token.AND:      p.parseInfixExpression,
+		token.OR:       p.parseInfixExpression,
+		token.XOR:      p.parseInfixExpression,
+		token.XNOR:     p.parseInfixExpression,
 		token.LPAREN:   p.parseCallExpression,
 	}
 
@@ -55,9 +72,11 @@ func New(l *lexer.Lexer) *Parser {
 	return p
 }
 
-// Errors returns the errors that occured during parsing.
+// Errors returns the errors that occured during parsing, including any errors the lexer
+// encountered while reading the input.
 func (p *Parser) Errors() []error {
-	return p.errors
+	errs := append([]error{}, p.l.Errors()...)
+	return append(errs, p.errors...)
 }
 
 // addError adds an error to the parser's internal error list.
@@ -90,6 +109,8 @@ func (p *Parser) Parse() *ast.Program {
 
 	// parse loop section
 	if !p.curTokenIs(token.EOF) {
+		p.inLoop = true
+
 		for {
 			if p.curTokenIs(token.EOF) {
 				break
@@ -133,6 +154,16 @@ func (p *Parser) parseStatement() ast.Statement {
 	}
 
 	switch p.curToken.Type {
+	case token.DIRECTIVE:
+		return &ast.Directive{Tok: p.curToken, Name: p.curToken.Literal[1:]}
+
+	case token.M_PLUS, token.M_MINUS:
+		// By itself, M+ or M- adds or subtracts Ans.
+		return &ast.MemoryStatement{Tok: p.curToken, Operator: p.curToken.Literal[1:]}
+
+	case token.CONST:
+		return p.parseConstantDeclaration()
+
 	case token.QUESTION_MARK:
 		if !p.peekTokenIs(token.ASSIGN_TO) {
 			p.addError(NewInvalidTokenError(p.curToken, token.Token{
@@ -145,8 +176,13 @@ func (p *Parser) parseStatement() ast.Statement {
 			return nil
 		}
 
-		p.nextToken()
-		p.nextToken()
+		p.nextToken() // current token is now ->
+
+		if !p.expectPeek(token.IDENT) {
+			p.addError(NewUnexpectedTokenError(p.curToken, p.peekToken, token.IDENT))
+			p.skipStatement()
+			return nil
+		}
 
 		var stmt ast.Statement
 
@@ -160,11 +196,34 @@ func (p *Parser) parseStatement() ast.Statement {
 
 	default:
 		expr := p.parseExpression(LOWEST)
+		if expr == nil {
+			p.skipStatement()
+			return nil
+		}
+
+		if p.peekTokenIs(token.DEFINE) {
+			return p.parseFunctionDefinition(expr)
+		}
+
 		var stmt ast.Statement
 
-		if p.peekTokenIs(token.ASSIGN_TO) {
+		if p.peekTokenIs(token.M_PLUS) || p.peekTokenIs(token.M_MINUS) {
+			p.nextToken() // current token is now M+ or M-
+
+			stmt = &ast.MemoryStatement{
+				Tok:      p.curToken,
+				Operator: p.curToken.Literal[1:],
+				Value:    expr,
+			}
+		} else if p.peekTokenIs(token.ASSIGN_TO) {
 			p.nextToken() // current token is now ->
-			p.nextToken() // current token is now the start of identifier
+
+			// Keywords such as `not` are reserved, so can't be assigned to.
+			if !p.expectPeek(token.IDENT) {
+				p.addError(NewUnexpectedTokenError(p.curToken, p.peekToken, token.IDENT))
+				p.skipStatement()
+				return nil
+			}
 
 			ident, _ := p.parseIdentifier().(*ast.Identifier)
 			stmt = &ast.VariableAssignment{
@@ -184,13 +243,100 @@ func (p *Parser) parseStatement() ast.Statement {
 
 }
 
+// parseConstantDeclaration parses a declaration like `const 17/91 -> FRAC_A`, which is an
+// ordinary variable assignment with the identifier marked as a constant.
+func (p *Parser) parseConstantDeclaration() ast.Statement {
+	tok := p.curToken
+
+	if p.inLoop {
+		p.addError(NewInvalidConstantError(tok, p.peekToken, "constants must be declared before the loop section"))
+		p.skipStatement()
+		return nil
+	}
+
+	if p.peekTokenIs(token.NEWLINE) || p.peekTokenIs(token.COLON) || p.peekTokenIs(token.EOF) {
+		p.addError(NewInvalidConstantError(tok, p.peekToken, "expected an assignment like `const 17/91 -> FRAC_A`"))
+		return nil
+	}
+
+	p.nextToken()
+
+	stmt := p.parseStatement()
+	if stmt == nil {
+		return nil
+	}
+
+	assignment, ok := stmt.(*ast.VariableAssignment)
+	if !ok || assignment.Name == nil {
+		p.addError(NewInvalidConstantError(tok, stmt.Token(), "expected an assignment like `const 17/91 -> FRAC_A`"))
+		return nil
+	}
+
+	assignment.Name.Constant = true
+
+	return assignment
+}
+
+// parseFunctionDefinition parses a definition like `f(X) := X*X`, where the left hand
+// side has already been parsed as a subroutine call.
+func (p *Parser) parseFunctionDefinition(signature ast.Expression) ast.Statement {
+	p.nextToken() // current token is now :=
+
+	call, ok := signature.(*ast.SubroutineCall)
+	if !ok {
+		p.addError(NewInvalidDefinitionError(p.curToken, p.peekToken, signature.String(), "expected a function name and parameters, such as f(X)"))
+		p.skipStatement()
+		return nil
+	}
+
+	name, ok := call.Subroutine.(*ast.Identifier)
+	if !ok {
+		p.addError(NewInvalidDefinitionError(p.curToken, p.peekToken, signature.String(), "function name must be an identifier"))
+		p.skipStatement()
+		return nil
+	}
+
+	def := &ast.FunctionDefinition{Tok: p.curToken, Name: name}
+	seen := map[string]bool{}
+
+	for _, arg := range call.Arguments {
+		param, ok := arg.(*ast.Identifier)
+		if !ok {
+			p.addError(NewInvalidDefinitionError(p.curToken, p.peekToken, signature.String(), "parameter "+arg.String()+" is not an identifier"))
+			p.skipStatement()
+			return nil
+		}
+
+		if seen[param.Value] {
+			p.addError(NewInvalidDefinitionError(p.curToken, p.peekToken, signature.String(), "parameter "+param.Value+" appears more than once"))
+			p.skipStatement()
+			return nil
+		}
+
+		seen[param.Value] = true
+		def.Parameters = append(def.Parameters, param)
+	}
+
+	p.nextToken() // current token is now the start of the body
+
+	def.Body = p.parseExpression(LOWEST)
+	if def.Body == nil {
+		p.skipStatement()
+		return nil
+	}
+
+	return def
+}
+
 // Expression Parsing
 func (p *Parser) parseExpression(precedence int) ast.Expression {
 	prefix := p.prefixParseFns[p.curToken.Type]
 	if prefix == nil {
-		p.addError(
-			NewNoPrefixParseFnError(p.curToken, p.peekToken, p.curToken.Type),
-		)
+		if !p.reportedByLexer(p.curToken) {
+			p.addError(
+				NewNoPrefixParseFnError(p.curToken, p.peekToken, p.curToken.Type),
+			)
+		}
 
 		return nil
 	}
@@ -214,9 +360,60 @@ func (p *Parser) parseIdentifier() ast.Expression {
 }
 
 func (p *Parser) parseIntegerLiteral() ast.Expression {
+	literal, symbol := token.SplitEngineeringSymbol(p.curToken.Literal)
+	exponent := token.EngineeringSymbols[symbol]
+
+	// Symbols like m or μ scale an integer into a fraction.
+	if exponent < 0 {
+		return p.parseFloatLiteral()
+	}
+
 	lit := &ast.IntegerLiteral{Tok: p.curToken}
 
-	value, err := strconv.ParseInt(p.curToken.Literal, 0, 64)
+	value, err := strconv.ParseInt(literal, integerBase(literal), 64)
+	if err != nil {
+		return p.parseBigIntegerLiteral(literal, exponent)
+	}
+
+	for i := 0; i < exponent; i++ {
+		if value > math.MaxInt64/10 || value < math.MinInt64/10 {
+			return p.parseBigIntegerLiteral(literal, exponent)
+		}
+
+		value *= 10
+	}
+
+	lit.Value = value
+	return lit
+}
+
+// parseBigIntegerLiteral parses an integer literal which is too large for an int64, such
+// as 99999999999999999999 or 10000000T.
+func (p *Parser) parseBigIntegerLiteral(literal string, exponent int) ast.Expression {
+	value, ok := new(big.Int).SetString(literal, integerBase(literal))
+	if !ok {
+		p.addError(
+			NewIntegerParseError(p.curToken, p.peekToken, p.curToken.Literal),
+		)
+		return nil
+	}
+
+	value.Mul(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+
+	return &ast.BigIntegerLiteral{Tok: p.curToken, Value: value}
+}
+
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Tok: p.curToken}
+
+	// Scaling by an engineering symbol is done by parsing the number in exponent
+	// notation, so that `4.7k` is exactly the same as `4.7e3`.
+	literal, symbol := token.SplitEngineeringSymbol(p.curToken.Literal)
+	if symbol != "" {
+		literal = fmt.Sprintf("%se%d", literal, token.EngineeringSymbols[symbol])
+	}
+
+	value, err := strconv.ParseFloat(literal, 64)
 	if err != nil {
 		p.addError(
 			NewFloatParseError(p.curToken, p.peekToken, p.curToken.Literal),
@@ -228,10 +425,10 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	return lit
 }
 
-func (p *Parser) parseFloatLiteral() ast.Expression {
-	lit := &ast.FloatLiteral{Tok: p.curToken}
+func (p *Parser) parseImaginaryLiteral() ast.Expression {
+	lit := &ast.ImaginaryLiteral{Tok: p.curToken}
 
-	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
+	value, err := strconv.ParseFloat(strings.TrimSuffix(p.curToken.Literal, "i"), 64)
 	if err != nil {
 		p.addError(
 			NewFloatParseError(p.curToken, p.peekToken, p.curToken.Literal),
@@ -243,6 +440,44 @@ func (p *Parser) parseFloatLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseDMSLiteral() ast.Expression {
+	lit := &ast.DMSLiteral{Tok: p.curToken}
+
+	degrees, rest, _ := strings.Cut(p.curToken.Literal, "°")
+	minutes, rest, found := strings.Cut(rest, "'")
+	if !found {
+		minutes, rest = "", minutes
+	}
+	seconds := strings.TrimSuffix(rest, "\"")
+
+	parts := []struct {
+		str   string
+		value *float64
+	}{
+		{degrees, &lit.Degrees},
+		{minutes, &lit.Minutes},
+		{seconds, &lit.Seconds},
+	}
+
+	for _, part := range parts {
+		if part.str == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(part.str, 64)
+		if err != nil {
+			p.addError(
+				NewFloatParseError(p.curToken, p.peekToken, p.curToken.Literal),
+			)
+			return nil
+		}
+
+		*part.value = value
+	}
+
+	return lit
+}
+
 func (p *Parser) parseCallExpression(left ast.Expression) ast.Expression {
 	// some expression ( 1, 2 )
 	//                 ^
@@ -253,6 +488,14 @@ func (p *Parser) parseCallExpression(left ast.Expression) ast.Expression {
 func (p *Parser) parseSubroutineCall(expression ast.Expression) ast.Expression {
 	exp := &ast.SubroutineCall{Tok: p.curToken, Subroutine: expression}
 	exp.Arguments = p.parseCallArguments()
+
+	// An argument that couldn't be parsed, such as a keyword, has already been reported.
+	for _, arg := range exp.Arguments {
+		if arg == nil {
+			return nil
+		}
+	}
+
 	return exp
 }
 
@@ -280,10 +523,109 @@ func (p *Parser) parseCallArguments() []ast.Expression {
 	return args
 }
 
+// parseBracketLiteral parses either a vector such as [1, 2, 3] or a matrix such as
+// [[1, 2], [3, 4]], depending on whether the first element is a row.
+func (p *Parser) parseBracketLiteral() ast.Expression {
+	if p.peekTokenIs(token.LBRACKET) {
+		return p.parseMatrixLiteral()
+	}
+
+	lit := &ast.VectorLiteral{Tok: p.curToken}
+
+	elements, ok := p.parseExpressionList(token.RBRACKET)
+	if !ok {
+		return nil
+	}
+
+	lit.Elements = elements
+	return lit
+}
+
+// parseListLiteral parses a list such as {1, 2, 3}.
+func (p *Parser) parseListLiteral() ast.Expression {
+	lit := &ast.ListLiteral{Tok: p.curToken}
+
+	elements, ok := p.parseExpressionList(token.RBRACE)
+	if !ok {
+		return nil
+	}
+
+	lit.Elements = elements
+	return lit
+}
+
+// parseMatrixLiteral parses a matrix such as [[1, 2], [3, 4]], where each row is written
+// inside its own brackets.
+func (p *Parser) parseMatrixLiteral() ast.Expression {
+	lit := &ast.MatrixLiteral{Tok: p.curToken}
+
+	for {
+		if !p.expectPeek(token.LBRACKET) {
+			p.addError(NewUnexpectedTokenError(p.curToken, p.peekToken, token.LBRACKET))
+			return nil
+		}
+
+		row, ok := p.parseExpressionList(token.RBRACKET)
+		if !ok {
+			return nil
+		}
+
+		lit.Rows = append(lit.Rows, row)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	if !p.expectPeek(token.RBRACKET) {
+		p.addError(NewUnexpectedTokenError(p.curToken, p.peekToken, token.RBRACKET))
+		return nil
+	}
+
+	return lit
+}
+
+// parseExpressionList parses a comma-separated list of expressions, up to and including
+// the end token. It returns false if the list is invalid, having added an error.
+func (p *Parser) parseExpressionList(end token.Type) ([]ast.Expression, bool) {
+	list := []ast.Expression{}
+
+	if p.peekTokenIs(end) {
+		p.nextToken()
+		return list, true
+	}
+
+	for {
+		p.nextToken()
+
+		exp := p.parseExpression(LOWEST)
+		if exp == nil {
+			return nil, false
+		}
+
+		list = append(list, exp)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	if !p.expectPeek(end) {
+		p.addError(NewUnexpectedTokenError(p.curToken, p.peekToken, end))
+		return nil, false
+	}
+
+	return list, true
+}
+
 func (p *Parser) parsePrefixExpression() ast.Expression {
 	exp := &ast.PrefixExpression{
 		Tok:      p.curToken,
-		Operator: p.curToken.Literal,
+		Operator: operator(p.curToken),
 	}
 
 	p.nextToken()
@@ -296,7 +638,7 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	var expression = &ast.InfixExpression{
 		Tok:      p.curToken,
 		Left:     left,
-		Operator: p.curToken.Literal,
+		Operator: operator(p.curToken),
 	}
 
 	precedence := p.curPrecedence()
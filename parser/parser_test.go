@@ -104,6 +104,414 @@ func TestFloatLiteralExpression(t *testing.T) {
 
 }
 
+func TestNumberLiteralValues(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"0x1F", int64(31)},
+		{"0b1011", int64(11)},
+		{"0o17", int64(15)},
+		{"010", int64(10)},
+		{"1.5e-3", 0.0015},
+		{"1.5E+10", 1.5e10},
+		{"2e5", 200000.0},
+		{"4.7k", 4700.0},
+		{"10k", int64(10000)},
+		{"2M", int64(2000000)},
+		{"10μ", 0.00001},
+		{"3.3n", 3.3e-9},
+		{"1p", 1e-12},
+	}
+
+	for _, tt := range tests {
+		_, program := parseProgram(t, tt.input)
+
+		stmt, ok := program.Init.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Init.Statements[0])
+		}
+
+		switch expected := tt.expected.(type) {
+		case int64:
+			literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+			if assert.True(t, ok, "exp not *ast.IntegerLiteral for input %q. got=%T", tt.input, stmt.Expression) {
+				assert.Equal(t, expected, literal.Value, "wrong value for input %q", tt.input)
+			}
+		case float64:
+			literal, ok := stmt.Expression.(*ast.FloatLiteral)
+			if assert.True(t, ok, "exp not *ast.FloatLiteral for input %q. got=%T", tt.input, stmt.Expression) {
+				assert.InDelta(t, expected, literal.Value, 1e-12, "wrong value for input %q", tt.input)
+			}
+		}
+	}
+}
+
+func TestDMSLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{`12°34'56"`, 12 + 34.0/60 + 56.0/3600},
+		{`12°30'`, 12.5},
+		{`0°0'36"`, 0.01},
+		{`90°`, 90},
+	}
+
+	for _, tt := range tests {
+		_, program := parseProgram(t, tt.input)
+
+		stmt, ok := program.Init.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Init.Statements[0])
+		}
+
+		literal, ok := stmt.Expression.(*ast.DMSLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.DMSLiteral. got=%T", stmt.Expression)
+		}
+
+		assert.InDelta(t, tt.expected, literal.Value(), 1e-12, "wrong value for input %q", tt.input)
+	}
+}
+
+func TestImaginaryLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"2i", 2},
+		{"0.5i", 0.5},
+		{"1.5e3i", 1500},
+	}
+
+	for _, tt := range tests {
+		_, program := parseProgram(t, tt.input)
+
+		stmt, ok := program.Init.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Init.Statements[0])
+		}
+
+		literal, ok := stmt.Expression.(*ast.ImaginaryLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.ImaginaryLiteral. got=%T", stmt.Expression)
+		}
+
+		assert.Equal(t, tt.expected, literal.Value, "wrong value for input %q", tt.input)
+		assert.Equal(t, tt.input, literal.String(), "wrong string for input %q", tt.input)
+	}
+}
+
+func TestMatrixLiteral(t *testing.T) {
+	_, program := parseProgram(t, "[[1, 2 * a], [3, -4]] -> MatA")
+
+	stmt, ok := program.Init.Statements[0].(*ast.VariableAssignment)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.VariableAssignment. got=%T", program.Init.Statements[0])
+	}
+
+	matrix, ok := stmt.Value.(*ast.MatrixLiteral)
+	if !ok {
+		t.Fatalf("stmt.Value not *ast.MatrixLiteral. got=%T", stmt.Value)
+	}
+
+	if assert.Len(t, matrix.Rows, 2, "matrix has wrong number of rows") {
+		assert.Len(t, matrix.Rows[0], 2, "row 1 has wrong number of elements")
+		assert.Len(t, matrix.Rows[1], 2, "row 2 has wrong number of elements")
+	}
+
+	assert.Equal(t, "[[1, (2 * a)], [3, (-4)]]", matrix.String())
+}
+
+func TestVectorLiteral(t *testing.T) {
+	_, program := parseProgram(t, "[1, 2 * a, -3] -> VctA")
+
+	stmt, ok := program.Init.Statements[0].(*ast.VariableAssignment)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.VariableAssignment. got=%T", program.Init.Statements[0])
+	}
+
+	vector, ok := stmt.Value.(*ast.VectorLiteral)
+	if !ok {
+		t.Fatalf("stmt.Value not *ast.VectorLiteral. got=%T", stmt.Value)
+	}
+
+	assert.Len(t, vector.Elements, 3, "vector has wrong number of elements")
+	assert.Equal(t, "[1, (2 * a), (-3)]", vector.String())
+}
+
+func TestListLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		length   int
+		expected string
+	}{
+		{"{1, 2.5, -3}", 3, "{1, 2.5, (-3)}"},
+		{"{X * 2}", 1, "{(X * 2)}"},
+		{"{}", 0, "{}"},
+	}
+
+	for _, tt := range tests {
+		_, program := parseProgram(t, tt.input)
+
+		stmt, ok := program.Init.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Init.Statements[0])
+		}
+
+		list, ok := stmt.Expression.(*ast.ListLiteral)
+		if !ok {
+			t.Fatalf("stmt.Expression not *ast.ListLiteral. got=%T", stmt.Expression)
+		}
+
+		assert.Len(t, list.Elements, tt.length, "list has wrong number of elements for input %q", tt.input)
+		assert.Equal(t, tt.expected, list.String())
+	}
+}
+
+func TestMatrixLiteralErrors(t *testing.T) {
+	tests := []string{
+		"[[1, 2]",
+		"[[1, 2] [3, 4]]",
+		"[[1, 2",
+		"[1, 2",
+		"[1 2]",
+		"{1, 2",
+		"{1 2}",
+	}
+
+	for _, input := range tests {
+		p := New(lexer.New(input))
+		p.Parse()
+
+		errs := p.Errors()
+		if assert.NotEmpty(t, errs, "expected an error for input %q", input) {
+			assert.IsType(t, UnexpectedTokenError{}, errs[0], "wrong error type for input %q", input)
+		}
+	}
+}
+
+func TestDirective(t *testing.T) {
+	input := `%rad
+SIN(pi)`
+
+	_, program := parseProgram(t, input)
+
+	if len(program.Init.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d", 2, len(program.Init.Statements))
+	}
+
+	directive, ok := program.Init.Statements[0].(*ast.Directive)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.Directive. got=%T", program.Init.Statements[0])
+	}
+
+	assert.Equal(t, "rad", directive.Name, "directive.Name should equal 'rad'")
+	assert.Equal(t, "%rad", directive.String(), "directive.String() should equal '%rad'")
+}
+
+func TestMemoryStatement(t *testing.T) {
+	input := `3*4 M+
+Ans M-
+M+
+M-
+M+1 -> M`
+
+	_, program := parseProgram(t, input)
+
+	if len(program.Init.Statements) != 5 {
+		t.Fatalf("program.Init.Statements does not contain %d statements. got=%d", 5, len(program.Init.Statements))
+	}
+
+	tests := []struct {
+		expectedOperator string
+		expectedString   string
+	}{
+		{"+", "(3 * 4) M+"},
+		{"-", "Ans M-"},
+		{"+", "M+"},
+		{"-", "M-"},
+	}
+
+	for i, tt := range tests {
+		stmt, ok := program.Init.Statements[i].(*ast.MemoryStatement)
+		if !ok {
+			t.Fatalf("program.Init.Statements[%d] is not ast.MemoryStatement. got=%T", i, program.Init.Statements[i])
+		}
+
+		assert.Equal(t, tt.expectedOperator, stmt.Operator, "stmt.Operator wrong")
+		assert.Equal(t, tt.expectedString, stmt.String(), "stmt.String() wrong")
+	}
+
+	assert.IsType(t, &ast.VariableAssignment{}, program.Init.Statements[4])
+}
+
+func TestConstantDeclarations(t *testing.T) {
+	input := `const 17/91 -> FRAC_A
+5 -> x
+:::
+FRAC_A * x -> x`
+
+	_, program := parseProgram(t, input)
+
+	if len(program.Init.Statements) != 2 {
+		t.Fatalf("program.Init.Statements does not contain %d statements. got=%d", 2, len(program.Init.Statements))
+	}
+
+	tests := []struct {
+		expectedIdent    string
+		expectedConstant bool
+	}{
+		{"FRAC_A", true},
+		{"x", false},
+	}
+
+	for i, tt := range tests {
+		stmt, ok := program.Init.Statements[i].(*ast.VariableAssignment)
+		if !ok {
+			t.Fatalf("program.Init.Statements[%d] is not ast.VariableAssignment. got=%T", i, program.Init.Statements[i])
+		}
+
+		assert.Equal(t, tt.expectedIdent, stmt.Name.Value, "stmt.Name.Value wrong")
+		assert.Equal(t, tt.expectedConstant, stmt.Name.Constant, "stmt.Name.Constant wrong for %s", tt.expectedIdent)
+	}
+
+	assert.Equal(t, "const (17 / 91) -> FRAC_A", program.Init.Statements[0].String())
+}
+
+func TestConstantDeclarationErrors(t *testing.T) {
+	tests := []string{
+		"const 5",
+		"const",
+		"const P(2)",
+		"1 -> A\n:::\nconst 2 -> B",
+	}
+
+	for _, input := range tests {
+		p := New(lexer.New(input))
+		p.Parse()
+
+		errs := p.Errors()
+		if assert.Len(t, errs, 1, "expected exactly one error for input %q. got=%v", input, errs) {
+			assert.IsType(t, InvalidConstantError{}, errs[0], "wrong error type for input %q", input)
+		}
+	}
+}
+
+func TestFunctionDefinition(t *testing.T) {
+	input := `f(X, Y) := X*X + Y
+f(2, 3)`
+
+	_, program := parseProgram(t, input)
+
+	if len(program.Init.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d", 2, len(program.Init.Statements))
+	}
+
+	def, ok := program.Init.Statements[0].(*ast.FunctionDefinition)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.FunctionDefinition. got=%T", program.Init.Statements[0])
+	}
+
+	assert.Equal(t, "f", def.Name.Value, "def.Name.Value should equal 'f'")
+	if assert.Len(t, def.Parameters, 2, "def.Parameters has wrong length") {
+		assert.Equal(t, "X", def.Parameters[0].Value)
+		assert.Equal(t, "Y", def.Parameters[1].Value)
+	}
+	assert.Equal(t, "((X * X) + Y)", def.Body.String(), "def.Body.String() wrong")
+}
+
+func TestFunctionDefinitionErrors(t *testing.T) {
+	tests := []string{
+		"f(2) := 3",
+		"f(X, X) := X",
+		"3 := X",
+	}
+
+	for _, input := range tests {
+		p := New(lexer.New(input))
+		p.Parse()
+
+		errs := p.Errors()
+		if assert.Len(t, errs, 1, "expected exactly one error for input %q. got=%v", input, errs) {
+			assert.IsType(t, InvalidDefinitionError{}, errs[0], "wrong error type for input %q", input)
+		}
+	}
+}
+
+// Keywords are reserved in every mode, not just BASE-N mode.
+func TestReservedWordErrors(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedType interface{}
+	}{
+		{"P(1) -> NOT", UnexpectedTokenError{}},
+		{"1 -> and", UnexpectedTokenError{}},
+		{"? -> XOR", UnexpectedTokenError{}},
+		{"const 1 -> neg", UnexpectedTokenError{}},
+		{"f(and) := 1", NoPrefixParseFnError{}},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.Parse()
+
+		errs := p.Errors()
+		if assert.NotEmpty(t, errs, "expected an error for input %q", tt.input) {
+			assert.IsType(t, tt.expectedType, errs[0], "wrong error type for input %q", tt.input)
+		}
+	}
+}
+
+func TestBigIntegerLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"99999999999999999999", "99999999999999999999"},
+		{"10000000T", "10000000000000000000"},
+		{"0x10000000000000000", "18446744073709551616"},
+	}
+
+	for _, tt := range tests {
+		_, program := parseProgram(t, tt.input)
+
+		stmt, ok := program.Init.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Init.Statements[0])
+		}
+
+		literal, ok := stmt.Expression.(*ast.BigIntegerLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.BigIntegerLiteral for input %q. got=%T", tt.input, stmt.Expression)
+		}
+
+		assert.Equal(t, tt.expected, literal.String(), "wrong value for input %q", tt.input)
+		assert.Equal(t, tt.input, literal.Tok.Literal, "wrong token literal for input %q", tt.input)
+	}
+}
+
+func TestNumberLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedType interface{}
+	}{
+		{"1.2.3", lexer.MalformedNumberError{}},
+		{"0b102 -> A", lexer.MalformedNumberError{}},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.Parse()
+
+		errs := p.Errors()
+		if assert.Len(t, errs, 1, "expected exactly one error for input %q. got=%v", tt.input, errs) {
+			assert.IsType(t, tt.expectedType, errs[0], "wrong error type for input %q", tt.input)
+		}
+	}
+}
+
 func TestParsingPrefixExpressions(t *testing.T) {
 	tests := []struct {
 		input        string
@@ -150,6 +558,7 @@ func TestParsingInfixExpressions(t *testing.T) {
 		{"5 - 5", 5, "-", 5},
 		{"5 * 5", 5, "*", 5},
 		{"5 / 5", 5, "/", 5},
+		{"5 ÷R 5", 5, "÷R", 5},
 	}
 
 	for _, tt := range tests {
@@ -212,6 +621,34 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"a + b / c",
 			"(a + (b / c))",
 		},
+		{
+			"a * a + b = c - d",
+			"(((a * a) + b) = (c - d))",
+		},
+		{
+			"solve(x * x = 2, x, 1)",
+			"solve(((x * x) = 2), x, 1)",
+		},
+		{
+			"a + b ÷R c",
+			"(a + (b ÷R c))",
+		},
+		{
+			"3 - 4i * i",
+			"(3 - (4i * i))",
+		},
+		{
+			"a or b and c",
+			"(a or (b and c))",
+		},
+		{
+			"a + b AND c xor d",
+			"(((a + b) and c) xor d)",
+		},
+		{
+			"not a xnor neg b * c",
+			"((not a) xnor ((neg b) * c))",
+		},
 		{
 			"a + b * c + d / e - f",
 			"(((a + (b * c)) + (d / e)) - f)",
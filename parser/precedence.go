@@ -6,17 +6,26 @@ import "github.com/ollybritton/calclang/token"
 const (
 	_ int = iota
 	LOWEST
-	SUM     // + or -
-	PRODUCT // * or /
-	PREFIX  // -X
-	CALL    // fn(x)
+	EQUATION // L = R
+	BIT_OR   // or, xor or xnor
+	BIT_AND  // and
+	SUM      // + or -
+	PRODUCT  // *, / or ÷R
+	PREFIX   // -X, not X or neg X
+	CALL     // fn(x)
 )
 
 // Mappings of precedences to their token types.
 var precedences = map[token.Type]int{
+	token.EQUALS:   EQUATION,
+	token.OR:       BIT_OR,
+	token.XOR:      BIT_OR,
+	token.XNOR:     BIT_OR,
+	token.AND:      BIT_AND,
 	token.PLUS:     SUM,
 	token.MINUS:    SUM,
 	token.SLASH:    PRODUCT,
 	token.ASTERISK: PRODUCT,
+	token.DIV_REM:  PRODUCT,
 	token.LPAREN:   CALL,
 }
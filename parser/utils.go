@@ -1,6 +1,11 @@
 package parser
 
-import "github.com/ollybritton/calclang/token"
+import (
+	"strings"
+
+	"github.com/ollybritton/calclang/lexer"
+	"github.com/ollybritton/calclang/token"
+)
 
 func (p *Parser) curTokenIs(tt token.Type) bool {
 	return p.curToken.Type == tt
@@ -20,6 +25,15 @@ func (p *Parser) expectPeek(tt token.Type) bool {
 	return false
 }
 
+// skipStatement advances the parser to the last token of the current statement, so that
+// one invalid statement doesn't cause a cascade of errors.
+func (p *Parser) skipStatement() {
+	for !p.peekTokenIs(token.NEWLINE) && !p.peekTokenIs(token.COLON) &&
+		!p.peekTokenIs(token.TRIPLE_COLON) && !p.peekTokenIs(token.EOF) {
+		p.nextToken()
+	}
+}
+
 func (p *Parser) curPrecedence() int {
 	if p, ok := precedences[p.curToken.Type]; ok {
 		return p
@@ -35,3 +49,39 @@ func (p *Parser) peekPrecedence() int {
 
 	return LOWEST
 }
+
+// reportedByLexer returns true if the lexer has already reported an error for the given
+// token, such as a malformed number, so that the parser doesn't report it twice.
+func (p *Parser) reportedByLexer(tok token.Token) bool {
+	for _, err := range p.l.Errors() {
+		if err, ok := err.(lexer.MalformedNumberError); ok && err.Tok == tok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// integerBase returns the base an integer literal should be parsed in. Literals with a
+// radix prefix such as `0x` are detected by strconv, but plain literals are always
+// decimal so that `010` is ten rather than octal.
+func integerBase(literal string) int {
+	if len(literal) > 2 && literal[0] == '0' {
+		switch literal[1] {
+		case 'x', 'X', 'b', 'B', 'o', 'O':
+			return 0
+		}
+	}
+
+	return 10
+}
+
+// operator gets the operator an infix or prefix token stands for. Keyword operators such
+// as AND can be written in either case, so they are always lowercase.
+func operator(tok token.Token) string {
+	if token.LookupKeyword(tok.Literal) != token.ILLEGAL {
+		return strings.ToLower(tok.Literal)
+	}
+
+	return tok.Literal
+}
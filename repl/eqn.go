@@ -0,0 +1,207 @@
+package repl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	au "github.com/logrusorgru/aurora"
+	"github.com/ollybritton/calclang/builtins"
+	"github.com/ollybritton/calclang/evaluator"
+	"github.com/ollybritton/calclang/object"
+)
+
+// Equation is an equation being entered in "eqn" or "ineq" mode, like EQN and INEQ mode
+// on the calculator. The coefficients are asked for one at a time, and the equation is
+// solved once they have all been entered.
+type Equation struct {
+	Kind         string            // Either "simul", "poly" or "ineq".
+	Size         int               // The number of unknowns, or the degree of the polynomial.
+	Relation     builtins.Relation // The comparison with 0, for inequalities.
+	Coefficients []float64         // The coefficients entered so far.
+}
+
+// Labels returns the names of the coefficients of the equation, in the order they are
+// entered. For a linear system, each equation's coefficients are numbered, such as a1, b1
+// and c1 for a1x+b1y=c1.
+func (e *Equation) Labels() []string {
+	letters := "abcde"
+
+	if e.Kind != "simul" {
+		return strings.Split(letters[:e.Size+1], "")
+	}
+
+	labels := []string{}
+	for row := 1; row <= e.Size; row++ {
+		for _, letter := range letters[:e.Size+1] {
+			labels = append(labels, fmt.Sprintf("%c%d", letter, row))
+		}
+	}
+
+	return labels
+}
+
+// Solve solves the equation once every coefficient has been entered.
+func (e *Equation) Solve() object.Object {
+	if e.Kind == "ineq" {
+		return builtins.SolveInequality(e.Coefficients, e.Relation)
+	}
+
+	if e.Kind == "poly" {
+		args := []object.Object{}
+		for _, c := range e.Coefficients {
+			args = append(args, &object.Float{Value: c})
+		}
+
+		return builtins.BuiltinPoly(args...)
+	}
+
+	m := object.NewMatrix(e.Size, e.Size)
+	constants := &object.List{}
+
+	for i := range m.Values {
+		row := e.Coefficients[i*(e.Size+1) : (i+1)*(e.Size+1)]
+
+		copy(m.Values[i], row[:e.Size])
+		constants.Values = append(constants.Values, row[e.Size])
+	}
+
+	return builtins.BuiltinSimul(m, constants)
+}
+
+// EqnHelp prints how to start entering an equation in "eqn" mode.
+func EqnHelp() {
+	fmt.Println(au.Bold("Choose the type of equation to solve:"))
+	fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White("simul 2").Italic(), au.Green("Simultaneous linear equations with 2 to 4 unknowns")))
+	fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White("poly 2").Italic(), au.Green("A polynomial of degree 2 to 4, such as ax²+bx+c=0")))
+	fmt.Println("")
+}
+
+// IneqHelp prints how to start entering an inequality in "ineq" mode.
+func IneqHelp() {
+	fmt.Println(au.Bold("Choose the degree of the polynomial and the inequality to solve:"))
+	fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White("2 >").Italic(), au.Green("Solve ax²+bx+c>0, or use >=, < or <=")))
+	fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White("4 <=").Italic(), au.Green("Solve ax⁴+bx³+cx²+dx+e≤0")))
+	fmt.Println("")
+}
+
+// Eqn handles a line of input in "eqn" or "ineq" mode. It either starts a new equation or
+// enters its next coefficient, which can be any expression.
+func (r *Repl) Eqn(input string) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return
+	}
+
+	if r.Equation == nil {
+		r.startEquation(input)
+		return
+	}
+
+	obj, errors := evaluator.EvalString(input, r.Env)
+	if len(errors) != 0 {
+		Errors(errors)
+		return
+	}
+
+	// Input such as a comment doesn't give a value, so the same coefficient is asked for
+	// again.
+	if obj == nil {
+		return
+	}
+
+	var value float64
+	switch obj := object.Value(obj).(type) {
+	case *object.Integer:
+		value = float64(obj.Value)
+	case *object.Float:
+		value = obj.Value
+	case *object.BigInteger:
+		value = object.BigIntegerToFloat(obj).Value
+	default:
+		fmt.Println(au.Red(fmt.Sprintf("Coefficients must be numbers, got=%s.", obj.Type())).Bold())
+		fmt.Println("")
+
+		return
+	}
+
+	r.Equation.Coefficients = append(r.Equation.Coefficients, value)
+	if len(r.Equation.Coefficients) < len(r.Equation.Labels()) {
+		return
+	}
+
+	r.showEquation(r.Equation, r.Equation.Solve())
+	r.Equation = nil
+}
+
+// startEquation starts entering an equation, given its type and size such as "poly 3". In
+// "ineq" mode, it is given the degree and relation instead, such as "2 >".
+func (r *Repl) startEquation(input string) {
+	fields := strings.Fields(strings.ToLower(input))
+
+	if r.Mode == "ineq" {
+		if len(fields) == 2 {
+			size, err := strconv.Atoi(fields[0])
+			relation, ok := builtins.ParseRelation(fields[1])
+
+			if err == nil && ok && size >= 2 && size <= 4 {
+				r.Equation = &Equation{Kind: "ineq", Size: size, Relation: relation}
+				return
+			}
+		}
+
+		fmt.Println(au.Red(fmt.Sprintf("Unknown inequality %q.", input)).Bold())
+		IneqHelp()
+
+		return
+	}
+
+	if len(fields) == 2 && (fields[0] == "simul" || fields[0] == "poly") {
+		size, err := strconv.Atoi(fields[1])
+		if err == nil && size >= 2 && size <= 4 {
+			r.Equation = &Equation{Kind: fields[0], Size: size}
+			return
+		}
+	}
+
+	fmt.Println(au.Red(fmt.Sprintf("Unknown equation %q.", input)).Bold())
+	EqnHelp()
+}
+
+// showEquation displays the solutions to an equation, one per line like the calculator.
+// Quadratics also show their minimum or maximum.
+func (r *Repl) showEquation(e *Equation, result object.Object) {
+	if result.Type() == object.ERROR_OBJ {
+		fmt.Println(au.Red(result.Inspect()).Bold())
+		fmt.Println("")
+
+		return
+	}
+
+	if e.Kind == "ineq" {
+		fmt.Println(au.Green(result.Inspect()))
+		fmt.Println("")
+
+		return
+	}
+
+	solution := result.(*object.EquationSolution)
+	for i, name := range solution.Names {
+		fmt.Println(au.Sprintf("%v = %v", au.White(name).Italic(), au.Green(r.display(solution.Values[i]).Inspect())))
+	}
+
+	if e.Kind == "poly" && e.Size == 2 {
+		a, b, c := e.Coefficients[0], e.Coefficients[1], e.Coefficients[2]
+		vertex := builtins.BuiltinVertex(&object.Float{Value: a}, &object.Float{Value: b}, &object.Float{Value: c}).(*object.Vector)
+
+		kind := "Minimum"
+		if a < 0 {
+			kind = "Maximum"
+		}
+
+		fmt.Println(au.Sprintf("%v = %v", au.White("x-Value "+kind).Italic(), au.Green(r.display(&object.Float{Value: vertex.Values[0]}).Inspect())))
+		fmt.Println(au.Sprintf("%v = %v", au.White("y-Value "+kind).Italic(), au.Green(r.display(&object.Float{Value: vertex.Values[1]}).Inspect())))
+	}
+
+	fmt.Println("")
+}
@@ -2,7 +2,9 @@ package repl
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/ollybritton/calclang/object"
 	"github.com/ollybritton/calclang/token"
 
 	au "github.com/logrusorgru/aurora"
@@ -75,6 +77,101 @@ func Help() {
 	fmt.Println(
 		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%eval").Italic(), au.Green("Evaluate the input (run command)")),
 	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%eqn").Italic(), au.Green("Solve equations, entering the coefficients one at a time")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%ineq").Italic(), au.Green("Solve polynomial inequalities, such as ax²+bx+c>0")),
+	)
+
+	fmt.Println("")
+	fmt.Println("Use the following commands to change how results are displayed:")
+
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%norm").Italic(), au.Green("Display results in ordinary notation")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%eng").Italic(), au.Green("Display results in engineering notation (4.7k)")),
+	)
+
+	fmt.Println("")
+	fmt.Println("Use the following commands to change the angle unit:")
+
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%deg").Italic(), au.Green("Measure angles in degrees (default)")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%rad").Italic(), au.Green("Measure angles in radians")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%grad").Italic(), au.Green("Measure angles in gradians")),
+	)
+
+	fmt.Println("")
+	fmt.Println("Use the following commands to work with complex numbers, such as 3+4i:")
+
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%cmplx").Italic(), au.Green("Allow complex results, such as SQRT(-1)")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%real").Italic(), au.Green("Only allow real results (default)")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%rect").Italic(), au.Green("Write complex numbers in rectangular form, 3+4i (default)")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%polar").Italic(), au.Green("Write complex numbers in polar form, 5∠53.13")),
+	)
+
+	fmt.Println("")
+	fmt.Println("Use the following commands for 32-bit integer work with and, or, xor, xnor, not and neg:")
+
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%dec").Italic(), au.Green("Use BASE-N mode, displaying integers in decimal")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%hex").Italic(), au.Green("Use BASE-N mode, displaying integers in hexadecimal")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%bin").Italic(), au.Green("Use BASE-N mode, displaying integers in binary")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%oct").Italic(), au.Green("Use BASE-N mode, displaying integers in octal")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%comp").Italic(), au.Green("Leave BASE-N and CMPLX modes (default)")),
+	)
+
+	fmt.Println("")
+	fmt.Println("Use the following command to look up scientific constants, such as @mp:")
+
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%doc").Italic(), au.Green("List the scientific constants, or describe one with %doc @mp")),
+	)
+
+	fmt.Println("")
+	fmt.Println("Use the following command to load data for statistics:")
+
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%load").Italic(), au.Green("Load each column of a CSV file into a list, such as %load data.csv")),
+	)
+
+	fmt.Println("")
+	fmt.Println("Use the following command to make a table of values:")
+
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("%table").Italic(), au.Green("Tabulate f(X) and g(X), such as %table X*X; 2*X from 1 to 5 step 0.5")),
+	)
+
+	fmt.Println("")
+	fmt.Println("Use the following to reuse results, like the Ans and M+ keys:")
+
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("Ans").Italic(), au.Green("The result of the last calculation, and PreAns the one before it")),
+	)
+	fmt.Println(
+		au.Sprintf(au.BrightWhite("%q -- %v"), au.White("3*4 M+").Italic(), au.Green("Add a result to the memory M, or subtract it with M-")),
+	)
 
 	fmt.Println("")
 	fmt.Println("The following useful commands are also avaliable")
@@ -90,3 +187,45 @@ func Help() {
 
 	fmt.Println("")
 }
+
+// Doc prints the description, value and unit of a scientific constant. If no name is
+// given, it lists all of them.
+func Doc(name string) {
+	fmt.Println("")
+
+	if name == "" {
+		category := ""
+
+		for _, c := range object.ScientificConstants {
+			if c.Category != category {
+				if category != "" {
+					fmt.Println("")
+				}
+
+				category = c.Category
+				fmt.Println(au.Bold(category))
+			}
+
+			fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White(c.Name()).Italic(), au.Green(c.Description)))
+		}
+
+		fmt.Println("")
+		return
+	}
+
+	if !object.IsScientificConstantName(name) {
+		name = object.SCIENTIFIC_PREFIX + name
+	}
+
+	c, ok := object.LookupScientificConstant(name)
+	if !ok {
+		fmt.Println(au.Red(au.Bold(fmt.Sprintf("No scientific constant %q found.", name))))
+		fmt.Println("")
+
+		return
+	}
+
+	fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White(c.Name()).Italic(), au.Green(c.Description)))
+	fmt.Println(au.Sprintf("%v %v %v", au.Cyan(strconv.FormatFloat(c.Value, 'g', -1, 64)), c.Unit, au.Faint("("+c.Category+")")))
+	fmt.Println("")
+}
@@ -21,15 +21,17 @@ type Repl struct {
 	Buffer bytes.Buffer
 	Prompt *prompt.Prompt
 
-	Mode  string // Either "lex", "parse" or "eval"
-	Level int
+	Mode    string         // Either "lex", "parse", "eval", "eqn" or "ineq"
+	Display object.Display // How results are displayed in "eval" mode, such as NORM or ENG.
+	Level   int
 
-	Env *object.Environment
+	Env      *object.Environment
+	Equation *Equation // The equation being entered in "eqn" or "ineq" mode, if any.
 }
 
 // New returns a new, initialised REPL.
 func New() *Repl {
-	r := &Repl{Mode: "eval"}
+	r := &Repl{Mode: "eval", Display: object.DISPLAY_NORM}
 	r.Prompt = prompt.New(
 		r.Execute,
 		r.Completor,
@@ -55,6 +57,21 @@ func New() *Repl {
 func (r *Repl) Execute(input string) {
 	r.Level = 0
 
+	if input == "%doc" || strings.HasPrefix(input, "%doc ") {
+		Doc(strings.TrimSpace(input[len("%doc"):]))
+		return
+	}
+
+	if input == "%table" || strings.HasPrefix(input, "%table ") {
+		r.Table(strings.TrimSpace(input[len("%table"):]))
+		return
+	}
+
+	if strings.HasPrefix(input, "%load ") {
+		r.Load(strings.TrimSpace(input[len("%load"):]))
+		return
+	}
+
 	if strings.HasPrefix(input, "%") {
 		switch input[1:] {
 		case "lex", "tokenize", "split":
@@ -78,6 +95,70 @@ func (r *Repl) Execute(input string) {
 
 			return
 
+		case "eqn":
+			r.Mode = "eqn"
+			r.Equation = nil
+			fmt.Println(au.Green("Mode set to 'eqn'."))
+			fmt.Println("")
+			EqnHelp()
+
+			return
+
+		case "ineq":
+			r.Mode = "ineq"
+			r.Equation = nil
+			fmt.Println(au.Green("Mode set to 'ineq'."))
+			fmt.Println("")
+			IneqHelp()
+
+			return
+
+		case "norm", "eng":
+			r.Display = object.Display(strings.ToUpper(input[1:]))
+			fmt.Println(au.Green(fmt.Sprintf("Display set to '%s'.", r.Display)))
+			fmt.Println("")
+
+			return
+
+		case "deg", "rad", "grad":
+			unit, _ := object.ParseAngleUnit(input[1:])
+			r.Env.SetAngleUnit(unit)
+			fmt.Println(au.Green(fmt.Sprintf("Angle unit set to '%s'.", unit)))
+			fmt.Println("")
+
+			return
+
+		case "cmplx", "real":
+			r.Env.SetComplexMode(input[1:] == "cmplx")
+			fmt.Println(au.Green(fmt.Sprintf("Mode set to '%s'.", strings.ToUpper(input[1:]))))
+			fmt.Println("")
+
+			return
+
+		case "rect", "polar":
+			format, _ := object.ParseComplexFormat(input[1:])
+			r.Env.SetComplexFormat(format)
+			fmt.Println(au.Green(fmt.Sprintf("Complex format set to '%s'.", format)))
+			fmt.Println("")
+
+			return
+
+		case "dec", "hex", "bin", "oct":
+			radix, _ := object.ParseRadix(input[1:])
+			r.Env.SetRadix(radix)
+			fmt.Println(au.Green(fmt.Sprintf("Mode set to 'BASE-N', radix set to '%s'.", radix)))
+			fmt.Println("")
+
+			return
+
+		case "comp":
+			r.Env.SetRadix("")
+			r.Env.SetComplexMode(false)
+			fmt.Println(au.Green("Mode set to 'COMP'."))
+			fmt.Println("")
+
+			return
+
 		case "buf":
 			input = Buffer(false)
 
@@ -119,6 +200,8 @@ func (r *Repl) Execute(input string) {
 		r.Parse(input)
 	case "eval":
 		r.Eval(input)
+	case "eqn", "ineq":
+		r.Eqn(input)
 	}
 }
 
@@ -129,11 +212,21 @@ func (r *Repl) Completor(input prompt.Document) []prompt.Suggest {
 		return []prompt.Suggest{}
 	}
 
+	if object.IsScientificConstantName(w) {
+		return prompt.FilterHasPrefix(scientificSuggestions(), w, false)
+	}
+
 	return prompt.FilterHasPrefix(suggestions, w, true)
 }
 
-// Prefix is what calculates the prefix/identation level.
+// Prefix is what calculates the prefix/identation level. In "eqn" and "ineq" mode, it asks
+// for the next coefficient.
 func (r *Repl) Prefix() (string, bool) {
+	if r.Equation != nil && (r.Mode == "eqn" || r.Mode == "ineq") {
+		label := r.Equation.Labels()[len(r.Equation.Coefficients)]
+		return "(" + r.Mode + ") " + label + " = ", true
+	}
+
 	if r.Level > 0 {
 		indent := strings.Repeat("  ", r.Level)
 		return "(" + r.Mode + ") > " + indent, true
@@ -196,10 +289,53 @@ func (r *Repl) Eval(input string) {
 		return
 	}
 
-	fmt.Println(au.Green(obj.Inspect()))
+	fmt.Println(au.Green(r.display(obj).Inspect()))
 	fmt.Println("")
 }
 
+// Load reads the columns of a CSV file into lists, and displays the lists it created.
+func (r *Repl) Load(path string) {
+	names, err := r.Env.LoadCSV(path)
+	if err != nil {
+		fmt.Println(au.Red("Could not load " + path + ":").Bold())
+		fmt.Println(au.Red(err))
+		fmt.Println("")
+
+		return
+	}
+
+	for _, name := range names {
+		list, _ := r.Env.Get(name)
+		fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White(name).Italic(), au.Green(list.Inspect())))
+	}
+
+	fmt.Println("")
+}
+
+// display applies the REPL's display setting to a result, unless the result has already
+// been given a display of its own, such as by ENG(x). Complex results and BASE-N integers
+// are formatted using the environment's settings instead.
+func (r *Repl) display(obj object.Object) object.Object {
+	switch obj.(type) {
+	case *object.Complex:
+		return r.Env.Format(obj)
+	case *object.Integer:
+		if r.Env.Radix() != "" {
+			return r.Env.Format(obj)
+		}
+	}
+
+	if r.Display == object.DISPLAY_NORM {
+		return obj
+	}
+
+	if f, ok := obj.(*object.Float); ok && f.Display != "" {
+		return obj
+	}
+
+	return object.WithDisplay(obj, r.Display)
+}
+
 // Start starts the REPL.
 func (r *Repl) Start() {
 	Info()
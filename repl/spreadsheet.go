@@ -0,0 +1,326 @@
+package repl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+	au "github.com/logrusorgru/aurora"
+	"github.com/ollybritton/calclang/object"
+	"github.com/ollybritton/calclang/spreadsheet"
+)
+
+// The size of the grid drawn by the spreadsheet UI.
+const (
+	SHEET_ROWS = 12 // The number of rows shown at once.
+	CELL_WIDTH = 12 // The width of each column, including the space between them.
+)
+
+var sheetSuggestions = []prompt.Suggest{
+	{Text: "help", Description: "Print help text."},
+	{Text: "copy", Description: "Copy a cell, moving relative references, like copy A1 B1:B5."},
+	{Text: "fill", Description: "Fill a range with a formula or number, like fill =A1*2 B1:B5."},
+	{Text: "clear", Description: "Clear a cell or range, like clear A1:B3."},
+	{Text: "goto", Description: "Scroll the grid to a row, like goto 20."},
+	{Text: "load", Description: "Load a sheet from a CSV file, like load sheet.csv."},
+	{Text: "save", Description: "Save the sheet's numbers and formulas as CSV."},
+	{Text: "export", Description: "Save the value of every cell as CSV, like export values.csv."},
+	{Text: "quit", Description: "Exit the spreadsheet."},
+}
+
+// Spreadsheet is a terminal UI for SPREADSHEET mode. The grid is drawn before each
+// command, and cells are changed by entering the cell followed by a number or formula,
+// such as `A1 =B1*2`.
+type Spreadsheet struct {
+	Sheet  *spreadsheet.Sheet
+	Prompt *prompt.Prompt
+
+	Path    string // The CSV file the sheet is saved to by default.
+	Top     int    // The first row shown in the grid, counting from 0.
+	Message string // A message shown below the grid after a command, such as an error.
+}
+
+// NewSpreadsheet returns a new spreadsheet UI for editing a sheet.
+func NewSpreadsheet(sheet *spreadsheet.Sheet, path string) *Spreadsheet {
+	s := &Spreadsheet{Sheet: sheet, Path: path}
+	s.Prompt = prompt.New(
+		s.Execute,
+		s.Completor,
+
+		prompt.OptionPrefix("(sheet) > "),
+		prompt.OptionTitle("calclang spreadsheet"),
+
+		prompt.OptionSuggestionBGColor(prompt.Red),
+		prompt.OptionSuggestionTextColor(prompt.Black),
+
+		prompt.OptionSelectedSuggestionBGColor(prompt.Red),
+		prompt.OptionSelectedDescriptionBGColor(prompt.Turquoise),
+		prompt.OptionSelectedDescriptionTextColor(prompt.Black),
+		prompt.OptionSelectedSuggestionTextColor(prompt.Black),
+
+		prompt.OptionInputTextColor(prompt.Turquoise),
+	)
+
+	return s
+}
+
+// Start starts the spreadsheet UI, redrawing the grid after every command.
+func (s *Spreadsheet) Start() {
+	for {
+		s.Draw()
+
+		input := strings.TrimSpace(s.Prompt.Input())
+		if input == "quit" || input == "exit" {
+			return
+		}
+
+		s.Execute(input)
+	}
+}
+
+// Completor suggests the spreadsheet's commands.
+func (s *Spreadsheet) Completor(d prompt.Document) []prompt.Suggest {
+	w := d.GetWordBeforeCursor()
+	if w == "" || strings.Contains(d.TextBeforeCursor(), " ") {
+		return []prompt.Suggest{}
+	}
+
+	return prompt.FilterHasPrefix(sheetSuggestions, w, true)
+}
+
+// Execute runs a single command, such as `A1 =B1*2` or `copy A1 B1:B5`.
+func (s *Spreadsheet) Execute(input string) {
+	s.Message = ""
+
+	command, rest, _ := strings.Cut(strings.TrimSpace(input), " ")
+	rest = strings.TrimSpace(rest)
+
+	switch strings.ToLower(command) {
+	case "":
+		return
+
+	case "help":
+		s.Message = sheetHelp
+
+	case "copy":
+		fields := strings.Fields(strings.ToUpper(rest))
+		if len(fields) != 2 {
+			s.Message = "Use copy A1 B1, or copy A1 B1:B5 to copy into a range."
+			return
+		}
+
+		origin, ok := spreadsheet.ParseCell(fields[0])
+		from, to, ok2 := spreadsheet.ParseRange(fields[1])
+		if !ok || !ok2 {
+			s.Message = fmt.Sprintf("Unknown cells %q.", rest)
+			return
+		}
+
+		s.check(s.Sheet.Fill(s.Sheet.Input(origin), origin, from, to))
+
+	case "fill":
+		i := strings.LastIndex(rest, " ")
+		if i == -1 {
+			s.Message = "Use fill =A1*2 B1:B5, giving the formula for the top left cell."
+			return
+		}
+
+		from, to, ok := spreadsheet.ParseRange(strings.ToUpper(rest[i+1:]))
+		if !ok {
+			s.Message = fmt.Sprintf("Unknown range %q.", rest[i+1:])
+			return
+		}
+
+		s.check(s.Sheet.Fill(rest[:i], from, from, to))
+
+	case "clear":
+		from, to, ok := spreadsheet.ParseRange(strings.ToUpper(rest))
+		if !ok {
+			s.Message = "Use clear A1, or clear A1:B3 to clear a range."
+			return
+		}
+
+		s.check(s.Sheet.Fill("", from, from, to))
+
+	case "goto":
+		row, err := strconv.Atoi(strings.TrimLeft(strings.ToUpper(rest), "ABCDE"))
+		if err != nil || row < 1 || row > spreadsheet.ROWS {
+			s.Message = fmt.Sprintf("Use goto followed by a row from 1 to %d.", spreadsheet.ROWS)
+			return
+		}
+
+		s.Top = min(row-1, spreadsheet.ROWS-SHEET_ROWS)
+
+	case "load":
+		s.load(rest)
+
+	case "save":
+		if rest == "" {
+			rest = s.Path
+		}
+
+		s.save(rest, s.Sheet.WriteCSV)
+
+	case "export":
+		s.save(rest, s.Sheet.WriteValuesCSV)
+
+	default:
+		c, ok := spreadsheet.ParseCell(strings.ToUpper(command))
+		if !ok {
+			s.Message = fmt.Sprintf("Unknown command %q, use help to list the commands.", command)
+			return
+		}
+
+		if rest == "" {
+			s.show(c)
+			return
+		}
+
+		s.check(s.Sheet.Set(c, rest))
+	}
+}
+
+// show describes what was entered into a cell and its value.
+func (s *Spreadsheet) show(c spreadsheet.Cell) {
+	input := s.Sheet.Input(c)
+	if input == "" {
+		s.Message = c.String() + " is empty."
+		return
+	}
+
+	s.Message = fmt.Sprintf("%s: %s = %s", c, input, s.Sheet.Value(c).Inspect())
+}
+
+// check shows an error from changing the sheet, if there was one.
+func (s *Spreadsheet) check(err error) {
+	if err != nil {
+		s.Message = err.Error()
+	}
+}
+
+// load replaces the sheet with one read from a CSV file.
+func (s *Spreadsheet) load(path string) {
+	if path == "" {
+		s.Message = "Use load followed by the name of a CSV file."
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		s.Message = err.Error()
+		return
+	}
+	defer file.Close()
+
+	sheet, err := spreadsheet.ReadCSV(file, s.Sheet.Env)
+	if err != nil {
+		s.Message = fmt.Sprintf("Could not load %s: %s", path, err)
+		return
+	}
+
+	s.Sheet, s.Path, s.Top = sheet, path, 0
+	s.Message = "Loaded " + path + "."
+}
+
+// save writes the sheet to a CSV file using one of the sheet's CSV writers.
+func (s *Spreadsheet) save(path string, write func(w io.Writer) error) {
+	if path == "" {
+		s.Message = "Use save or export followed by the name of a CSV file."
+		return
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		s.Message = err.Error()
+		return
+	}
+	defer file.Close()
+
+	if err := write(file); err != nil {
+		s.Message = fmt.Sprintf("Could not save %s: %s", path, err)
+		return
+	}
+
+	s.Message = "Saved " + path + "."
+}
+
+// Draw clears the terminal and draws the visible rows of the grid, followed by the
+// message from the last command.
+func (s *Spreadsheet) Draw() {
+	fmt.Print("\033[H\033[2J")
+
+	header := strings.Repeat(" ", 4)
+	for column := 0; column < spreadsheet.COLUMNS; column++ {
+		header += pad(string(rune('A'+column)), CELL_WIDTH)
+	}
+
+	fmt.Println(au.Bold(header))
+
+	for row := s.Top; row < s.Top+SHEET_ROWS && row < spreadsheet.ROWS; row++ {
+		fmt.Print(au.Bold(fmt.Sprintf("%3d ", row+1)))
+
+		for column := 0; column < spreadsheet.COLUMNS; column++ {
+			value := s.Sheet.Value(spreadsheet.Cell{Column: column, Row: row})
+
+			switch {
+			case value == nil:
+				fmt.Print(pad("", CELL_WIDTH))
+			case value.Type() == object.ERROR_OBJ:
+				fmt.Print(au.Red(pad("ERROR", CELL_WIDTH)))
+			default:
+				fmt.Print(au.Green(pad(fitNumber(value, CELL_WIDTH-1), CELL_WIDTH)))
+			}
+		}
+
+		fmt.Println()
+	}
+
+	fmt.Println("")
+
+	if s.Message != "" {
+		fmt.Println(au.Yellow(s.Message))
+		fmt.Println("")
+	}
+}
+
+// sheetHelp describes the commands that can be used in the spreadsheet UI.
+const sheetHelp = `Enter a cell followed by a number or a formula, such as A1 5 or B1 =A1*2+$A$1.
+Formulas can use Sum, Mean, Min and Max on a range, such as =Mean(A1:A10).
+Entering a cell on its own, such as A1, shows what is in it.
+
+copy A1 B1:B5 -- Copy a cell, moving its relative references
+fill =A1*2 B1:B5 -- Fill a range, as if the formula was entered in the top left cell
+clear A1:B3 -- Clear a cell or range
+goto 20 -- Scroll the grid to a row
+load sheet.csv -- Load a sheet from a CSV file
+save sheet.csv -- Save the numbers and formulas as CSV
+export values.csv -- Save the value of every cell as CSV
+quit -- Exit the spreadsheet`
+
+// pad right-aligns text in a column of the given width.
+func pad(text string, width int) string {
+	if len(text) >= width {
+		return text
+	}
+
+	return strings.Repeat(" ", width-len(text)) + text
+}
+
+// fitNumber writes the value of a cell so that it fits in the given width, using fewer
+// significant figures if it needs to.
+func fitNumber(value object.Object, width int) string {
+	f, ok := value.(*object.Float)
+	if !ok {
+		return value.Inspect()
+	}
+
+	text := f.Inspect()
+	for figures := 10; len(text) > width && figures > 1; figures-- {
+		text = strconv.FormatFloat(f.Value, 'g', figures, 64)
+	}
+
+	return text
+}
@@ -1,6 +1,9 @@
 package repl
 
-import "github.com/c-bata/go-prompt"
+import (
+	"github.com/c-bata/go-prompt"
+	"github.com/ollybritton/calclang/object"
+)
 
 var suggestions = []prompt.Suggest{
 	{Text: "%help", Description: "Print help text."},
@@ -8,7 +11,51 @@ var suggestions = []prompt.Suggest{
 	{Text: "%lex", Description: "Put the REPL into lex mode."},
 	{Text: "%parse", Description: "Put the REPL into parse mode."},
 	{Text: "%eval", Description: "Put the REPL into eval mode."},
+	{Text: "%eqn", Description: "Put the REPL into eqn mode, to solve equations."},
+	{Text: "%ineq", Description: "Put the REPL into ineq mode, to solve inequalities."},
+
+	{Text: "%norm", Description: "Display results in ordinary notation."},
+	{Text: "%eng", Description: "Display results in engineering notation."},
+
+	{Text: "%deg", Description: "Measure angles in degrees."},
+	{Text: "%rad", Description: "Measure angles in radians."},
+	{Text: "%grad", Description: "Measure angles in gradians."},
+
+	{Text: "%cmplx", Description: "Allow complex results, such as SQRT(-1)."},
+	{Text: "%real", Description: "Only allow real results."},
+	{Text: "%rect", Description: "Write complex numbers in rectangular form (3+4i)."},
+	{Text: "%polar", Description: "Write complex numbers in polar form (5∠53.13)."},
+
+	{Text: "%dec", Description: "Use BASE-N mode, displaying integers in decimal."},
+	{Text: "%hex", Description: "Use BASE-N mode, displaying integers in hexadecimal."},
+	{Text: "%bin", Description: "Use BASE-N mode, displaying integers in binary."},
+	{Text: "%oct", Description: "Use BASE-N mode, displaying integers in octal."},
+	{Text: "%comp", Description: "Leave BASE-N and CMPLX modes."},
+
+	{Text: "%doc", Description: "Describe the scientific constants, or one like %doc @mp."},
+	{Text: "%load", Description: "Load the columns of a CSV file into lists, like %load data.csv."},
+	{Text: "%table", Description: "Tabulate f(X) and g(X), like %table X*X; 2*X from 1 to 5."},
+
+	{Text: "Ans", Description: "The result of the last calculation."},
+	{Text: "PreAns", Description: "The result of the calculation before the last one."},
 
 	{Text: "exit", Description: "Exit the REPL."},
 	{Text: "quit", Description: "Exit the REPL."},
 }
+
+// scientificSuggestions suggests the scientific constants, such as @mp, describing each
+// one along with its unit.
+func scientificSuggestions() []prompt.Suggest {
+	s := make([]prompt.Suggest, 0, len(object.ScientificConstants))
+
+	for _, c := range object.ScientificConstants {
+		description := c.Description
+		if c.Unit != "" {
+			description += " (" + c.Unit + ")"
+		}
+
+		s = append(s, prompt.Suggest{Text: c.Name(), Description: description})
+	}
+
+	return s
+}
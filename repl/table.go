@@ -0,0 +1,101 @@
+package repl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	au "github.com/logrusorgru/aurora"
+	"github.com/ollybritton/calclang/table"
+)
+
+// TableHelp prints how to use the %table command.
+func TableHelp() {
+	fmt.Println(au.Bold("Give f(X), and optionally g(X) after a semicolon, followed by the range of X:"))
+	fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White("%table X*X from 1 to 5").Italic(), au.Green("Tabulate X*X for X from 1 to 5 in steps of 1")))
+	fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White("%table X*X; 2*X from 0 to 1 step 0.1").Italic(), au.Green("Tabulate two functions in steps of 0.1")))
+	fmt.Println(au.Sprintf(au.BrightWhite("%v -- %v"), au.White("%table X*X from 1 to 5 as csv").Italic(), au.Green("Write the table as CSV or JSON")))
+	fmt.Println("")
+}
+
+// Table handles the %table command, printing a table of values for one or two functions
+// of X like TABLE mode, such as "%table X*X; 2*X from 0 to 1 step 0.1".
+func (r *Repl) Table(input string) {
+	functions, bounds, format, ok := parseTable(input)
+	if !ok {
+		if input != "" {
+			fmt.Println(au.Red(fmt.Sprintf("Could not understand table %q.", input)).Bold())
+		}
+
+		TableHelp()
+
+		return
+	}
+
+	values := []float64{}
+	for _, bound := range bounds {
+		value, err := table.EvalNumber(bound, r.Env)
+		if err != nil {
+			fmt.Println(au.Red(err).Bold())
+			fmt.Println("")
+
+			return
+		}
+
+		values = append(values, value)
+	}
+
+	t, err := table.New(functions, values[0], values[1], values[2], r.Env)
+	if err != nil {
+		fmt.Println(au.Red(err).Bold())
+		fmt.Println("")
+
+		return
+	}
+
+	if err := t.Write(os.Stdout, format); err != nil {
+		fmt.Println(au.Red(err).Bold())
+	}
+
+	fmt.Println("")
+}
+
+// parseTable splits a %table command into its functions, the start, end and step, and the
+// format to write the table in. The step defaults to 1 and the format to text.
+func parseTable(input string) ([]string, []string, table.Format, bool) {
+	format := table.FORMAT_TEXT
+
+	if i := strings.LastIndex(input, " as "); i != -1 {
+		f, ok := table.ParseFormat(strings.TrimSpace(input[i+len(" as "):]))
+		if !ok {
+			return nil, nil, "", false
+		}
+
+		format, input = f, input[:i]
+	}
+
+	i := strings.LastIndex(input, " from ")
+	if i == -1 {
+		return nil, nil, "", false
+	}
+
+	functions := strings.Split(input[:i], ";")
+	for j := range functions {
+		functions[j] = strings.TrimSpace(functions[j])
+		if functions[j] == "" {
+			return nil, nil, "", false
+		}
+	}
+
+	start, rest, ok := strings.Cut(input[i+len(" from "):], " to ")
+	if !ok || len(functions) > 2 {
+		return nil, nil, "", false
+	}
+
+	end, step, ok := strings.Cut(rest, " step ")
+	if !ok {
+		step = "1"
+	}
+
+	return functions, []string{start, end, step}, format, true
+}
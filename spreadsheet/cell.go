@@ -0,0 +1,167 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The size of the sheet, which matches the calculator's 5 columns and 45 rows.
+const (
+	COLUMNS = 5
+	ROWS    = 45
+)
+
+// Cell is the position of a cell in the sheet, counting from 0, so that A1 is column 0
+// and row 0.
+type Cell struct {
+	Column, Row int
+}
+
+// ParseCell parses the name of a cell, such as A1. Absolute references such as $A$1 are
+// accepted too, since the $ only matters when a formula is copied.
+func ParseCell(name string) (Cell, bool) {
+	ref, ok := parseReference(name)
+	return ref.Cell, ok
+}
+
+// ParseRange parses a range of cells such as A1:B3, or a single cell such as A1. The
+// first cell returned is always the top left corner of the range.
+func ParseRange(name string) (Cell, Cell, bool) {
+	first, last, found := strings.Cut(name, ":")
+	if !found {
+		last = first
+	}
+
+	from, ok := ParseCell(first)
+	if !ok {
+		return Cell{}, Cell{}, false
+	}
+
+	to, ok := ParseCell(last)
+	if !ok {
+		return Cell{}, Cell{}, false
+	}
+
+	from, to = corners(from, to)
+
+	return from, to, true
+}
+
+// corners returns the top left and bottom right corners of the range between two cells.
+func corners(a, b Cell) (Cell, Cell) {
+	return Cell{min(a.Column, b.Column), min(a.Row, b.Row)}, Cell{max(a.Column, b.Column), max(a.Row, b.Row)}
+}
+
+// String writes the name of a cell, such as A1.
+func (c Cell) String() string {
+	return fmt.Sprintf("%c%d", 'A'+c.Column, c.Row+1)
+}
+
+// Valid returns true if a cell is inside the sheet.
+func (c Cell) Valid() bool {
+	return c.Column >= 0 && c.Column < COLUMNS && c.Row >= 0 && c.Row < ROWS
+}
+
+// Cells returns every cell in the range from the top left cell to the bottom right one,
+// going along each row in turn.
+func Cells(from, to Cell) []Cell {
+	cells := []Cell{}
+	for row := from.Row; row <= to.Row; row++ {
+		for column := from.Column; column <= to.Column; column++ {
+			cells = append(cells, Cell{column, row})
+		}
+	}
+
+	return cells
+}
+
+// reference is a reference to a cell in a formula, such as A1 or $A$1. An absolute column
+// or row, marked with a $, stays the same when the formula is copied to another cell.
+type reference struct {
+	Cell
+	AbsoluteColumn, AbsoluteRow bool
+
+	start, end int // The position of the reference in the formula.
+}
+
+// String writes a reference the way it would be entered, such as $A1.
+func (r reference) String() string {
+	var out strings.Builder
+
+	if r.AbsoluteColumn {
+		out.WriteString("$")
+	}
+	out.WriteByte(byte('A' + r.Column))
+
+	if r.AbsoluteRow {
+		out.WriteString("$")
+	}
+	out.WriteString(strconv.Itoa(r.Row + 1))
+
+	return out.String()
+}
+
+// referencePattern matches something that looks like a cell reference. Whether it really is
+// one depends on what comes before and after it, since LOG10 isn't a reference to G10.
+var referencePattern = regexp.MustCompile(`\$?[A-Z]\$?[0-9]+`)
+
+// parseReference parses a single reference, such as $A1.
+func parseReference(name string) (reference, bool) {
+	if loc := referencePattern.FindStringIndex(name); loc == nil || loc[0] != 0 || loc[1] != len(name) {
+		return reference{}, false
+	}
+
+	ref := reference{}
+
+	if strings.HasPrefix(name, "$") {
+		ref.AbsoluteColumn, name = true, name[1:]
+	}
+
+	ref.Column, name = int(name[0]-'A'), name[1:]
+
+	if strings.HasPrefix(name, "$") {
+		ref.AbsoluteRow, name = true, name[1:]
+	}
+
+	row, err := strconv.Atoi(name)
+	if err != nil {
+		return reference{}, false
+	}
+
+	ref.Row = row - 1
+
+	return ref, ref.Valid()
+}
+
+// findReferences finds every cell reference in a formula, in the order they appear.
+func findReferences(formula string) []reference {
+	refs := []reference{}
+
+	for _, loc := range referencePattern.FindAllStringIndex(formula, -1) {
+		if loc[0] > 0 && isNamePart(formula[loc[0]-1]) {
+			continue
+		}
+
+		if loc[1] < len(formula) && (isNamePart(formula[loc[1]]) || formula[loc[1]] == '(') {
+			continue
+		}
+
+		ref, ok := parseReference(formula[loc[0]:loc[1]])
+		if !ok {
+			continue
+		}
+
+		ref.start, ref.end = loc[0], loc[1]
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// isNamePart returns true if a character could be part of an identifier or number, so
+// that a reference next to it is really part of something else.
+func isNamePart(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || '0' <= ch && ch <= '9' || ch == '_' || ch == '.' || ch == '@' || ch >= 0x80
+}
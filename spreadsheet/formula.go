@@ -0,0 +1,117 @@
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/lexer"
+	"github.com/ollybritton/calclang/parser"
+)
+
+// formula is a formula entered into a cell, such as =Sum(A1:A3)*2. Each cell it refers to
+// becomes a variable with the same name, and each range becomes a variable holding a list
+// of the numbers in it.
+type formula struct {
+	text    string       // The formula as entered, without the leading =.
+	program *ast.Program // The formula, with each range replaced by its variable.
+	cells   []Cell       // The cells referred to on their own.
+	ranges  [][2]Cell    // The top left and bottom right corners of each range.
+}
+
+// parseFormula parses the text of a formula, without the leading =.
+func parseFormula(text string) (*formula, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("Syntax ERROR: the formula is empty")
+	}
+
+	f := &formula{text: text}
+	refs := findReferences(text)
+
+	var expression strings.Builder
+	last := 0
+
+	for i := 0; i < len(refs); i++ {
+		expression.WriteString(text[last:refs[i].start])
+
+		if i+1 < len(refs) && text[refs[i].end:refs[i+1].start] == ":" {
+			from, to := corners(refs[i].Cell, refs[i+1].Cell)
+
+			f.ranges = append(f.ranges, [2]Cell{from, to})
+			expression.WriteString(rangeName(from, to))
+
+			last = refs[i+1].end
+			i++
+
+			continue
+		}
+
+		f.cells = append(f.cells, refs[i].Cell)
+		expression.WriteString(refs[i].Cell.String())
+
+		last = refs[i].end
+	}
+
+	expression.WriteString(text[last:])
+
+	p := parser.New(lexer.New(expression.String()))
+
+	program := p.Parse()
+	if len(p.Errors()) != 0 {
+		return nil, fmt.Errorf("Syntax ERROR: %w", p.Errors()[0])
+	}
+
+	if len(program.Loop.Statements) != 0 {
+		return nil, errors.New("Syntax ERROR: formulas can't contain a loop")
+	}
+
+	f.program = program
+
+	return f, nil
+}
+
+// dependencies returns every cell the formula refers to, including those inside ranges.
+func (f *formula) dependencies() []Cell {
+	cells := append([]Cell{}, f.cells...)
+	for _, r := range f.ranges {
+		cells = append(cells, Cells(r[0], r[1])...)
+	}
+
+	return cells
+}
+
+// rangeName is the name of the variable a range is replaced with, such as A1_B3 for A1:B3.
+func rangeName(from, to Cell) string {
+	return from.String() + "_" + to.String()
+}
+
+// shiftFormula moves every relative reference in a formula by the given number of columns
+// and rows, like copying the formula to another cell. Absolute references stay the same.
+func shiftFormula(text string, columns, rows int) (string, error) {
+	var out strings.Builder
+	last := 0
+
+	for _, ref := range findReferences(text) {
+		if !ref.AbsoluteColumn {
+			ref.Column += columns
+		}
+
+		if !ref.AbsoluteRow {
+			ref.Row += rows
+		}
+
+		if !ref.Valid() {
+			return "", fmt.Errorf("Ref ERROR: the formula %q would refer to a cell outside the sheet", text)
+		}
+
+		out.WriteString(text[last:ref.start])
+		out.WriteString(ref.String())
+
+		last = ref.end
+	}
+
+	out.WriteString(text[last:])
+
+	return out.String(), nil
+}
@@ -0,0 +1,415 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ollybritton/calclang/evaluator"
+	"github.com/ollybritton/calclang/object"
+)
+
+// Sheet is a grid of cells like SPREADSHEET mode on the calculator. Each cell is either
+// empty, a number, or a formula such as =A1*2 that is recalculated whenever a cell it
+// refers to changes.
+type Sheet struct {
+	Env *object.Environment // The environment formulas are evaluated in, for variables and settings.
+
+	constants map[Cell]float64
+	formulas  map[Cell]*formula
+	values    map[Cell]object.Object // The result of each cell that isn't empty, which may be an error.
+}
+
+// New creates an empty sheet whose formulas are evaluated in an environment enclosed by
+// env.
+func New(env *object.Environment) *Sheet {
+	return &Sheet{
+		Env:       env,
+		constants: make(map[Cell]float64),
+		formulas:  make(map[Cell]*formula),
+		values:    make(map[Cell]object.Object),
+	}
+}
+
+// Set enters something into a cell, like typing it in on the calculator. A formula starts
+// with =, such as =Sum(A1:A3). Anything else is evaluated straight away and must give a
+// number, and an empty input clears the cell. Formulas that would depend on their own cell
+// are rejected.
+func (s *Sheet) Set(c Cell, input string) error {
+	return s.change(func() error {
+		return s.set(c, input)
+	})
+}
+
+// Copy copies a cell to another, like copy and paste. Relative references in a formula are
+// moved along with it, so =A1*2 copied from B1 to B2 becomes =A2*2.
+func (s *Sheet) Copy(from, to Cell) error {
+	return s.Fill(s.Input(from), from, to, to)
+}
+
+// Fill enters something into every cell in a range, like Fill Formula on the calculator.
+// The input is written as if it was entered into the top left cell, and the relative
+// references in a formula are moved for each of the others.
+func (s *Sheet) Fill(input string, origin, from, to Cell) error {
+	return s.change(func() error {
+		for _, c := range Cells(from, to) {
+			shifted := input
+			if strings.HasPrefix(input, "=") {
+				var err error
+				if shifted, err = shiftFormula(input, c.Column-origin.Column, c.Row-origin.Row); err != nil {
+					return err
+				}
+			}
+
+			if err := s.set(c, shifted); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// change makes a change to the sheet and then recalculates it. If the change fails or
+// makes a formula depend on its own cell, the sheet is left as it was.
+func (s *Sheet) change(apply func() error) error {
+	constants := make(map[Cell]float64, len(s.constants))
+	for c, x := range s.constants {
+		constants[c] = x
+	}
+
+	formulas := make(map[Cell]*formula, len(s.formulas))
+	for c, f := range s.formulas {
+		formulas[c] = f
+	}
+
+	err := apply()
+	if err == nil {
+		for _, c := range s.formulaCells() {
+			if s.dependsOn(c, c, map[Cell]bool{}) {
+				err = fmt.Errorf("Circular ERROR: %s would depend on itself", c)
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		s.constants, s.formulas = constants, formulas
+		return err
+	}
+
+	s.Recalculate()
+
+	return nil
+}
+
+// set enters something into a cell without recalculating the sheet.
+func (s *Sheet) set(c Cell, input string) error {
+	if !c.Valid() {
+		return fmt.Errorf("Ref ERROR: %s is outside the sheet", c)
+	}
+
+	input = strings.TrimSpace(input)
+
+	switch {
+	case input == "":
+		delete(s.constants, c)
+		delete(s.formulas, c)
+
+	case strings.HasPrefix(input, "="):
+		f, err := parseFormula(strings.TrimSpace(input[1:]))
+		if err != nil {
+			return err
+		}
+
+		delete(s.constants, c)
+		s.formulas[c] = f
+
+	default:
+		obj, errs := evaluator.EvalString(input, object.NewEnclosedEnvironment(s.Env))
+		if len(errs) != 0 {
+			return errs[0]
+		}
+
+		x, ok := number(obj)
+		if !ok {
+			return fmt.Errorf("ArgumentERROR: cells must hold numbers, got=%s", obj.Type())
+		}
+
+		delete(s.formulas, c)
+		s.constants[c] = x
+	}
+
+	return nil
+}
+
+// dependsOn returns true if the formula in a cell refers to the target cell, either
+// directly or through the formulas in other cells.
+func (s *Sheet) dependsOn(c, target Cell, seen map[Cell]bool) bool {
+	f, ok := s.formulas[c]
+	if !ok {
+		return false
+	}
+
+	for _, dep := range f.dependencies() {
+		if dep == target {
+			return true
+		}
+
+		if !seen[dep] {
+			seen[dep] = true
+
+			if s.dependsOn(dep, target, seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Recalculate works out the value of every formula, after the cells they depend on.
+func (s *Sheet) Recalculate() {
+	s.values = make(map[Cell]object.Object)
+	for c, x := range s.constants {
+		s.values[c] = &object.Float{Value: x}
+	}
+
+	visiting := map[Cell]bool{}
+	for _, c := range s.formulaCells() {
+		s.evaluate(c, visiting)
+	}
+}
+
+// evaluate works out the value of a cell, first evaluating any formulas it depends on.
+// Formulas which end up depending on themselves, such as ones read from a CSV file, are
+// given an error rather than being evaluated forever.
+func (s *Sheet) evaluate(c Cell, visiting map[Cell]bool) object.Object {
+	if value, ok := s.values[c]; ok {
+		return value
+	}
+
+	f, ok := s.formulas[c]
+	if !ok {
+		return nil
+	}
+
+	if visiting[c] {
+		return &object.Error{Message: fmt.Sprintf("Circular ERROR: %s depends on itself", c)}
+	}
+
+	visiting[c] = true
+	value := s.calculate(f, visiting)
+	delete(visiting, c)
+
+	s.values[c] = value
+
+	return value
+}
+
+// calculate evaluates a formula in an environment where each cell it refers to is a
+// variable. Empty cells count as 0 on their own, but are left out of ranges so that
+// Mean(A1:A45) only counts the cells that have been filled in.
+func (s *Sheet) calculate(f *formula, visiting map[Cell]bool) object.Object {
+	env := object.NewEnclosedEnvironment(s.Env)
+
+	for _, c := range f.cells {
+		value := s.evaluate(c, visiting)
+		if value == nil {
+			value = &object.Float{Value: 0}
+		}
+
+		if value.Type() == object.ERROR_OBJ {
+			return value
+		}
+
+		if err, ok := env.Set(c.String(), value).(*object.Error); ok {
+			return err
+		}
+	}
+
+	for _, r := range f.ranges {
+		list := &object.List{Values: []float64{}}
+
+		for _, c := range Cells(r[0], r[1]) {
+			value := s.evaluate(c, visiting)
+			if value == nil {
+				continue
+			}
+
+			if value.Type() == object.ERROR_OBJ {
+				return value
+			}
+
+			x, _ := number(value)
+			list.Values = append(list.Values, x)
+		}
+
+		if err, ok := env.Set(rangeName(r[0], r[1]), list).(*object.Error); ok {
+			return err
+		}
+	}
+
+	result := evaluator.Eval(f.program, env)
+	if result == nil {
+		return &object.Error{Message: "Syntax ERROR: the formula doesn't give a value"}
+	}
+
+	if result.Type() == object.ERROR_OBJ {
+		return result
+	}
+
+	x, ok := number(result)
+	if !ok {
+		return &object.Error{Message: fmt.Sprintf("ArgumentERROR: cells must hold numbers, got=%s", result.Type())}
+	}
+
+	return &object.Float{Value: x}
+}
+
+// formulaCells returns every cell containing a formula, going along each row in turn so
+// that the sheet is always recalculated in the same order.
+func (s *Sheet) formulaCells() []Cell {
+	cells := []Cell{}
+	for c := range s.formulas {
+		cells = append(cells, c)
+	}
+
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].Row != cells[j].Row {
+			return cells[i].Row < cells[j].Row
+		}
+
+		return cells[i].Column < cells[j].Column
+	})
+
+	return cells
+}
+
+// Input returns what was entered into a cell, with formulas starting with =. Empty cells
+// give an empty string.
+func (s *Sheet) Input(c Cell) string {
+	if f, ok := s.formulas[c]; ok {
+		return "=" + f.text
+	}
+
+	if x, ok := s.constants[c]; ok {
+		return (&object.Float{Value: x}).Inspect()
+	}
+
+	return ""
+}
+
+// Value returns the value of a cell, which is an error if its formula couldn't be
+// evaluated. Empty cells give nil.
+func (s *Sheet) Value(c Cell) object.Object {
+	return s.values[c]
+}
+
+// Size returns the number of columns and rows that have been filled in, counting from A1.
+func (s *Sheet) Size() (int, int) {
+	columns, rows := 0, 0
+
+	for c := range s.values {
+		columns = max(columns, c.Column+1)
+		rows = max(rows, c.Row+1)
+	}
+
+	return columns, rows
+}
+
+// ReadCSV reads a sheet from a CSV file, where each cell is either a number or a formula
+// starting with =. Formulas are evaluated in an environment enclosed by env.
+func ReadCSV(r io.Reader, env *object.Environment) (*Sheet, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) > ROWS {
+		return nil, fmt.Errorf("the sheet can only have %d rows, got=%d", ROWS, len(records))
+	}
+
+	s := New(env)
+
+	for row, record := range records {
+		if len(record) > COLUMNS {
+			return nil, fmt.Errorf("the sheet can only have %d columns, got=%d on row %d", COLUMNS, len(record), row+1)
+		}
+
+		for column, input := range record {
+			c := Cell{column, row}
+			if err := s.set(c, input); err != nil {
+				return nil, fmt.Errorf("%s: %w", c, err)
+			}
+		}
+	}
+
+	s.Recalculate()
+
+	return s, nil
+}
+
+// WriteCSV writes what was entered into each cell as a CSV file, which can be read back
+// using ReadCSV.
+func (s *Sheet) WriteCSV(w io.Writer) error {
+	return s.writeCSV(w, s.Input)
+}
+
+// WriteValuesCSV writes the value of each cell as a CSV file, with errors written as
+// ERROR like the calculator.
+func (s *Sheet) WriteValuesCSV(w io.Writer) error {
+	return s.writeCSV(w, func(c Cell) string {
+		value := s.Value(c)
+
+		switch {
+		case value == nil:
+			return ""
+		case value.Type() == object.ERROR_OBJ:
+			return "ERROR"
+		default:
+			return value.Inspect()
+		}
+	})
+}
+
+// writeCSV writes the cells that have been filled in as a CSV file, using cell to write
+// each one.
+func (s *Sheet) writeCSV(w io.Writer, cell func(c Cell) string) error {
+	writer := csv.NewWriter(w)
+	columns, rows := s.Size()
+
+	for row := 0; row < rows; row++ {
+		record := make([]string, columns)
+		for column := range record {
+			record[column] = cell(Cell{column, row})
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// number converts the result of evaluating a cell into a number, if it is one.
+func number(obj object.Object) (float64, bool) {
+	switch obj := object.Value(obj).(type) {
+	case *object.Integer:
+		return float64(obj.Value), true
+	case *object.Float:
+		return obj.Value, true
+	case *object.BigInteger:
+		return object.BigIntegerToFloat(obj).Value, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,176 @@
+package spreadsheet_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ollybritton/calclang/object"
+	"github.com/ollybritton/calclang/spreadsheet"
+	"github.com/stretchr/testify/assert"
+)
+
+// cell parses the name of a cell, for tests where it is known to be valid.
+func cell(name string) spreadsheet.Cell {
+	c, _ := spreadsheet.ParseCell(name)
+	return c
+}
+
+// inspect returns the value of a cell the way it is written, or an empty string if the
+// cell is empty.
+func inspect(s *spreadsheet.Sheet, name string) string {
+	value := s.Value(cell(name))
+	if value == nil {
+		return ""
+	}
+
+	return value.Inspect()
+}
+
+func TestParseCell(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected spreadsheet.Cell
+		ok       bool
+	}{
+		{"A1", spreadsheet.Cell{Column: 0, Row: 0}, true},
+		{"E45", spreadsheet.Cell{Column: 4, Row: 44}, true},
+		{"$B$3", spreadsheet.Cell{Column: 1, Row: 2}, true},
+		{"F1", spreadsheet.Cell{}, false},
+		{"A46", spreadsheet.Cell{}, false},
+		{"A0", spreadsheet.Cell{}, false},
+		{"A", spreadsheet.Cell{}, false},
+	}
+
+	for _, tt := range tests {
+		c, ok := spreadsheet.ParseCell(tt.input)
+		assert.Equal(t, tt.ok, ok, "wrong validity for input %q", tt.input)
+
+		if tt.ok {
+			assert.Equal(t, tt.expected, c, "wrong cell for input %q", tt.input)
+		}
+	}
+}
+
+func TestFormulas(t *testing.T) {
+	s := spreadsheet.New(object.NewEnvironment())
+
+	inputs := []struct {
+		cell  string
+		input string
+	}{
+		{"A1", "1"},
+		{"A2", "2"},
+		{"A3", "3*2"},
+		{"B1", "=A1+A2*10"},
+		{"B2", "=Sum(A1:A3)"},
+		{"B3", "=Mean(A1:A45)"},
+		{"C1", "=Min(A1:B3)"},
+		{"C2", "=Max(A1:B3)"},
+		{"C3", "=D1+1"},
+		{"D1", "=1/0"},
+		{"D2", "=B1-$A$1"},
+	}
+
+	for _, in := range inputs {
+		assert.NoError(t, s.Set(cell(in.cell), in.input), "unexpected error for cell %s", in.cell)
+	}
+
+	expected := map[string]string{
+		"A3": "6", "B1": "21", "B2": "9", "B3": "3", "C1": "1", "C2": "21", "D2": "20", "E1": "",
+	}
+
+	for name, value := range expected {
+		assert.Equal(t, value, inspect(s, name), "wrong value for cell %s", name)
+	}
+
+	// Errors are passed on to the cells that depend on them.
+	assert.EqualValues(t, object.ERROR_OBJ, s.Value(cell("D1")).Type())
+	assert.EqualValues(t, object.ERROR_OBJ, s.Value(cell("C3")).Type())
+
+	// Changing a cell recalculates every formula that depends on it.
+	assert.NoError(t, s.Set(cell("A2"), "5"))
+	assert.Equal(t, "51", inspect(s, "B1"))
+	assert.Equal(t, "12", inspect(s, "B2"))
+	assert.Equal(t, "51", inspect(s, "C2"))
+	assert.Equal(t, "50", inspect(s, "D2"))
+
+	assert.NoError(t, s.Set(cell("A2"), ""))
+	assert.Equal(t, "1", inspect(s, "B1"))
+	assert.Equal(t, "3.5", inspect(s, "B3"))
+}
+
+func TestCopy(t *testing.T) {
+	s := spreadsheet.New(object.NewEnvironment())
+
+	assert.NoError(t, s.Set(cell("A1"), "2"))
+	assert.NoError(t, s.Set(cell("A2"), "3"))
+	assert.NoError(t, s.Set(cell("B1"), "=A1*$A$1+A$1"))
+
+	assert.NoError(t, s.Copy(cell("B1"), cell("B2")))
+	assert.Equal(t, "=A2*$A$1+A$1", s.Input(cell("B2")))
+	assert.Equal(t, "8", inspect(s, "B2"))
+
+	assert.NoError(t, s.Fill("=Sum($A$1:A1)", cell("C1"), cell("C1"), cell("C2")))
+	assert.Equal(t, "=Sum($A$1:A2)", s.Input(cell("C2")))
+	assert.Equal(t, "5", inspect(s, "C2"))
+
+	// Copying a formula to the left would refer to a column outside the sheet.
+	assert.Error(t, s.Copy(cell("B2"), cell("A1")))
+	assert.Equal(t, "2", s.Input(cell("A1")))
+}
+
+func TestSheetErrors(t *testing.T) {
+	s := spreadsheet.New(object.NewEnvironment())
+
+	assert.NoError(t, s.Set(cell("A1"), "=B1+1"))
+	assert.NoError(t, s.Set(cell("B1"), "=C1*2"))
+
+	tests := []struct {
+		cell  string
+		input string
+	}{
+		{"C1", "=A1"},
+		{"C1", "=Sum(A1:A3)"},
+		{"A1", "=A1"},
+		{"C1", "=1 +"},
+		{"C1", "="},
+		{"C1", "[1, 2]"},
+		{"C1", "unknown"},
+	}
+
+	for _, tt := range tests {
+		assert.Error(t, s.Set(cell(tt.cell), tt.input), "expected an error for %s %q", tt.cell, tt.input)
+	}
+
+	// The sheet is left as it was after an error.
+	assert.Equal(t, "=B1+1", s.Input(cell("A1")))
+	assert.Equal(t, "", s.Input(cell("C1")))
+	assert.Equal(t, "1", inspect(s, "A1"))
+}
+
+func TestCSV(t *testing.T) {
+	input := "1,=A1*2\n2,=A2*2\n,=Sum(B1:B2)\n"
+
+	s, err := spreadsheet.ReadCSV(strings.NewReader(input), object.NewEnvironment())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var formulas, values strings.Builder
+
+	assert.NoError(t, s.WriteCSV(&formulas))
+	assert.Equal(t, input, formulas.String())
+
+	assert.NoError(t, s.WriteValuesCSV(&values))
+	assert.Equal(t, "1,2\n2,4\n,6\n", values.String())
+
+	// Formulas read from a file that depend on themselves are given an error.
+	s, err = spreadsheet.ReadCSV(strings.NewReader("=B1,=A1\n"), object.NewEnvironment())
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, object.ERROR_OBJ, s.Value(cell("A1")).Type())
+		assert.EqualValues(t, object.ERROR_OBJ, s.Value(cell("B1")).Type())
+	}
+
+	_, err = spreadsheet.ReadCSV(strings.NewReader("1,2,3,4,5,6\n"), object.NewEnvironment())
+	assert.Error(t, err)
+}
@@ -0,0 +1,297 @@
+package table
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ollybritton/calclang/ast"
+	"github.com/ollybritton/calclang/evaluator"
+	"github.com/ollybritton/calclang/lexer"
+	"github.com/ollybritton/calclang/object"
+	"github.com/ollybritton/calclang/parser"
+)
+
+// MAX_ROWS is the most rows a table can have, so that a tiny step can't generate an
+// endless table.
+const MAX_ROWS = 1000
+
+// TABLE_FIGURES is the number of significant figures values in a table are rounded to,
+// hiding floating point error such as 0.1*0.1 giving 0.010000000000000002.
+const TABLE_FIGURES = 15
+
+// Format is how a table is written out.
+type Format string
+
+// Definition of formats.
+const (
+	FORMAT_TEXT Format = "text"
+	FORMAT_CSV  Format = "csv"
+	FORMAT_JSON Format = "json"
+)
+
+// ParseFormat parses the name of a format, such as csv.
+func ParseFormat(name string) (Format, bool) {
+	switch Format(strings.ToLower(name)) {
+	case FORMAT_TEXT:
+		return FORMAT_TEXT, true
+	case FORMAT_CSV:
+		return FORMAT_CSV, true
+	case FORMAT_JSON:
+		return FORMAT_JSON, true
+	default:
+		return "", false
+	}
+}
+
+// Table is a table of values for one or two functions of X, like TABLE mode on the
+// calculator.
+type Table struct {
+	Functions []string          // The functions being tabulated, f(X) and optionally g(X).
+	X         []float64         // The value of X in each row, rounded to TABLE_FIGURES.
+	Values    [][]object.Object // The value of each function in each row, or an error.
+}
+
+// New tabulates one or two functions of X from start to end. Each function is evaluated
+// in an environment enclosed by env, so it can use the variables and settings of env
+// without changing them.
+func New(functions []string, start, end, step float64, env *object.Environment) (*Table, error) {
+	if len(functions) < 1 || len(functions) > 2 {
+		return nil, fmt.Errorf("expected f(X) and optionally g(X), got %d functions", len(functions))
+	}
+
+	if step == 0 || math.IsNaN(step) || math.IsInf(step, 0) {
+		return nil, errors.New("the step must be a non-zero number")
+	}
+
+	if (end-start)/step < 0 {
+		return nil, errors.New("the step must go from the start towards the end")
+	}
+
+	// A little leeway stops rounding error from losing the last row, such as with a step
+	// of 0.1.
+	rows := math.Floor((end-start)/step+1e-9) + 1
+	if rows > MAX_ROWS {
+		return nil, fmt.Errorf("the table would have %.0f rows, the most allowed is %d", rows, MAX_ROWS)
+	}
+
+	programs := []*ast.Program{}
+	for _, function := range functions {
+		program, err := parse(function)
+		if err != nil {
+			return nil, err
+		}
+
+		programs = append(programs, program)
+	}
+
+	t := &Table{Functions: functions}
+
+	for i := 0; i < int(rows); i++ {
+		x := start + float64(i)*step
+		values := []object.Object{}
+
+		for _, program := range programs {
+			inner := object.NewEnclosedEnvironment(env)
+			inner.Set("X", &object.Float{Value: x})
+
+			values = append(values, evaluator.Eval(program, inner))
+		}
+
+		t.X = append(t.X, object.RoundSignificant(x, TABLE_FIGURES))
+		t.Values = append(t.Values, values)
+	}
+
+	return t, nil
+}
+
+// parse parses a function being tabulated. Functions with a loop section are rejected,
+// since evaluating them would never finish.
+func parse(function string) (*ast.Program, error) {
+	p := parser.New(lexer.New(function))
+
+	program := p.Parse()
+	if len(p.Errors()) != 0 {
+		return nil, fmt.Errorf("could not parse %q: %w", function, p.Errors()[0])
+	}
+
+	if len(program.Loop.Statements) != 0 {
+		return nil, fmt.Errorf("could not tabulate %q: functions can't contain a loop", function)
+	}
+
+	return program, nil
+}
+
+// EvalNumber evaluates an expression which should give a real number, such as the start,
+// end or step of a table. It is evaluated in an environment enclosed by env, so that it
+// doesn't change Ans.
+func EvalNumber(input string, env *object.Environment) (float64, error) {
+	obj, errs := evaluator.EvalString(input, object.NewEnclosedEnvironment(env))
+	if len(errs) != 0 {
+		return 0, errs[0]
+	}
+
+	switch obj := object.Value(obj).(type) {
+	case *object.Integer:
+		return float64(obj.Value), nil
+	case *object.Float:
+		return obj.Value, nil
+	case *object.BigInteger:
+		return object.BigIntegerToFloat(obj).Value, nil
+	default:
+		return 0, fmt.Errorf("%q should be a number, got=%s", input, obj.Type())
+	}
+}
+
+// Headers returns the name of each column, X followed by F(X) and G(X).
+func (t *Table) Headers() []string {
+	headers := []string{"X", "F(X)", "G(X)"}
+	return headers[:len(t.Functions)+1]
+}
+
+// Rows returns the table as text, each row starting with the value of X. Errors are
+// written as ERROR, like the calculator.
+func (t *Table) Rows() [][]string {
+	rows := [][]string{}
+
+	for i, x := range t.X {
+		row := []string{(&object.Float{Value: x}).Inspect()}
+		for _, value := range t.Values[i] {
+			row = append(row, cell(value))
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// Write writes the table in the given format.
+func (t *Table) Write(w io.Writer, format Format) error {
+	switch format {
+	case FORMAT_CSV:
+		return t.WriteCSV(w)
+	case FORMAT_JSON:
+		return t.WriteJSON(w)
+	default:
+		return t.WriteText(w)
+	}
+}
+
+// WriteText writes the table as aligned columns, numbered like the rows on the calculator.
+func (t *Table) WriteText(w io.Writer) error {
+	rows := append([][]string{append([]string{""}, t.Headers()...)}, t.numbered()...)
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for j, value := range row {
+			widths[j] = max(widths[j], utf8.RuneCountInString(value))
+		}
+	}
+
+	for _, row := range rows {
+		parts := []string{}
+		for j, value := range row {
+			parts = append(parts, strings.Repeat(" ", widths[j]-utf8.RuneCountInString(value))+value)
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(parts, "  ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// numbered returns the rows of the table, each starting with its row number.
+func (t *Table) numbered() [][]string {
+	rows := t.Rows()
+	for i, row := range rows {
+		rows[i] = append([]string{fmt.Sprint(i + 1)}, row...)
+	}
+
+	return rows
+}
+
+// WriteCSV writes the table as CSV, with a header row.
+func (t *Table) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(t.Headers()); err != nil {
+		return err
+	}
+
+	if err := writer.WriteAll(t.Rows()); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+// jsonRow is a row of a table written as JSON.
+type jsonRow struct {
+	X      float64       `json:"x"`
+	Values []interface{} `json:"values"`
+}
+
+// WriteJSON writes the table as JSON, listing the functions and then the value of each
+// one in every row. Numbers are written as numbers, errors as null and anything else,
+// such as a complex number, as a string.
+func (t *Table) WriteJSON(w io.Writer) error {
+	out := struct {
+		Functions []string  `json:"functions"`
+		Rows      []jsonRow `json:"rows"`
+	}{Functions: t.Functions, Rows: []jsonRow{}}
+
+	for i, x := range t.X {
+		row := jsonRow{X: x}
+		for _, value := range t.Values[i] {
+			row.Values = append(row.Values, jsonValue(value))
+		}
+
+		out.Rows = append(out.Rows, row)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(out)
+}
+
+// cell writes the value of a function as it appears in the table.
+func cell(value object.Object) string {
+	if value == nil || value.Type() == object.ERROR_OBJ {
+		return "ERROR"
+	}
+
+	if f, ok := object.Value(value).(*object.Float); ok && f.Display == "" {
+		return (&object.Float{Value: object.RoundSignificant(f.Value, TABLE_FIGURES)}).Inspect()
+	}
+
+	return object.Value(value).Inspect()
+}
+
+// jsonValue converts the value of a function into what is written as JSON.
+func jsonValue(value object.Object) interface{} {
+	if value == nil || value.Type() == object.ERROR_OBJ {
+		return nil
+	}
+
+	switch value := object.Value(value).(type) {
+	case *object.Integer:
+		return value.Value
+	case *object.Float:
+		if math.IsNaN(value.Value) || math.IsInf(value.Value, 0) {
+			return nil
+		}
+
+		return object.RoundSignificant(value.Value, TABLE_FIGURES)
+	default:
+		return value.Inspect()
+	}
+}
@@ -0,0 +1,94 @@
+package table_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ollybritton/calclang/object"
+	"github.com/ollybritton/calclang/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	tests := []struct {
+		functions []string
+		start     float64
+		end       float64
+		step      float64
+		format    table.Format
+		expected  string
+	}{
+		{
+			[]string{"X*X"}, 1, 3, 1, table.FORMAT_TEXT,
+			"   X  F(X)\n1  1     1\n2  2     4\n3  3     9\n",
+		},
+		{
+			[]string{"X*X", "1/X"}, -1, 1, 1, table.FORMAT_TEXT,
+			"    X  F(X)   G(X)\n1  -1     1     -1\n2   0     0  ERROR\n3   1     1      1\n",
+		},
+		{
+			[]string{"X*X", "2*X+1"}, 0, 0.3, 0.1, table.FORMAT_CSV,
+			"X,F(X),G(X)\n0,0,1\n0.1,0.01,1.2\n0.2,0.04,1.4\n0.3,0.09,1.6\n",
+		},
+		{
+			[]string{"X*X"}, 2, 1, -1, table.FORMAT_CSV,
+			"X,F(X)\n2,4\n1,1\n",
+		},
+		{
+			[]string{"1/X"}, 0, 1, 1, table.FORMAT_JSON,
+			`{
+  "functions": [
+    "1/X"
+  ],
+  "rows": [
+    {
+      "x": 0,
+      "values": [
+        null
+      ]
+    },
+    {
+      "x": 1,
+      "values": [
+        1
+      ]
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tab, err := table.New(tt.functions, tt.start, tt.end, tt.step, object.NewEnvironment())
+		if !assert.NoError(t, err, "unexpected error for functions %q", tt.functions) {
+			continue
+		}
+
+		var out strings.Builder
+		if assert.NoError(t, tab.Write(&out, tt.format)) {
+			assert.Equal(t, tt.expected, out.String(), "wrong table for functions %q", tt.functions)
+		}
+	}
+}
+
+func TestTableErrors(t *testing.T) {
+	tests := []struct {
+		functions []string
+		start     float64
+		end       float64
+		step      float64
+	}{
+		{[]string{}, 1, 5, 1},
+		{[]string{"X", "X", "X"}, 1, 5, 1},
+		{[]string{"X"}, 1, 5, 0},
+		{[]string{"X"}, 1, 5, -1},
+		{[]string{"X"}, 0, 1, 0.0001},
+		{[]string{"X +"}, 1, 5, 1},
+	}
+
+	for _, tt := range tests {
+		_, err := table.New(tt.functions, tt.start, tt.end, tt.step, object.NewEnvironment())
+		assert.Error(t, err, "expected an error for functions %q", tt.functions)
+	}
+}
@@ -37,15 +37,25 @@ const (
 	IDENT = "IDENT"
 	INT   = "INT"
 	FLOAT = "FLOAT"
+	DMS   = "DMS"  // Degrees-minutes-seconds, such as 12°34'56"
+	IMAG  = "IMAG" // An imaginary number, such as 2i
+
+	// Directives, such as %deg
+	DIRECTIVE = "DIRECTIVE"
 
 	// Operators
 	ASSIGN_TO     = "->"
+	EQUALS        = "="  // Separates the two sides of an equation given to SOLVE.
+	DEFINE        = ":=" // Defines a function, as in f(X) := X*X.
 	PLUS          = "+"
 	MINUS         = "-"
 	BANG          = "!"
 	ASTERISK      = "*"
 	SLASH         = "/"
+	DIV_REM       = "÷R" // Division with remainder, giving a quotient and a remainder.
 	QUESTION_MARK = "?"
+	M_PLUS        = "M+" // Adds to the independent memory M, as in `3*4 M+`.
+	M_MINUS       = "M-" // Subtracts from the independent memory M.
 
 	// Delimeters
 	COMMA        = ","
@@ -54,10 +64,23 @@ const (
 	TRIPLE_COLON = ":::"
 
 	// Brackets/Braces/Parenthesis
-	LPAREN = "("
-	RPAREN = ")"
-	LBRACE = "{"
-	RBRACE = "}"
+	LPAREN   = "("
+	RPAREN   = ")"
+	LBRACE   = "{"
+	RBRACE   = "}"
+	LBRACKET = "["
+	RBRACKET = "]"
+
+	// Keywords
+	CONST = "CONST"
+
+	// Bitwise operators, used in BASE-N mode.
+	AND  = "AND"
+	OR   = "OR"
+	XOR  = "XOR"
+	XNOR = "XNOR"
+	NOT  = "NOT"
+	NEG  = "NEG"
 )
 
 // NewToken returns a new token from a given Type, Literal and position in the source.
@@ -71,9 +94,19 @@ func NewToken(tokenType Type, lit string, line, startCol, endCol int) Token {
 	}
 }
 
-// Keywords maps the lowercase name of a keyword to the associated token.Type.
-// TODO: currently empty, remove?
-var Keywords = map[string]Type{}
+// Keywords maps the lowercase name of a keyword to the associated token.Type. Keywords are
+// reserved in every mode, so they can't be used as the names of variables, functions or
+// parameters, even though the logical operators only work in BASE-N mode.
+var Keywords = map[string]Type{
+	"const": CONST,
+
+	"and":  AND,
+	"or":   OR,
+	"xor":  XOR,
+	"xnor": XNOR,
+	"not":  NOT,
+	"neg":  NEG,
+}
 
 // LookupKeyword converts a keyword name into a keyword.
 // When checking if a given ident is a keyword, we only want to accept keywords that
@@ -103,3 +136,53 @@ func LookupIdent(ident string) Type {
 
 	return IDENT
 }
+
+// IsKeyword reports whether a name is read as a keyword rather than an identifier, such as
+// `not` or `NOT`.
+func IsKeyword(name string) bool {
+	return LookupIdent(name) != IDENT
+}
+
+// EngineeringSymbols maps the engineering symbols that can be written directly after a
+// number, such as the k in `4.7k`, to the power of ten they scale the number by.
+// μ can be written either as the Greek letter, the micro sign or an ASCII u.
+var EngineeringSymbols = map[string]int{
+	"T": 12,
+	"G": 9,
+	"M": 6,
+	"k": 3,
+	"m": -3,
+	"μ": -6,
+	"µ": -6,
+	"u": -6,
+	"n": -9,
+	"p": -12,
+}
+
+// EngineeringSymbol returns the symbol used to display a power of ten in ENG notation.
+// If there isn't a symbol for that power, an empty string is returned.
+func EngineeringSymbol(exponent int) string {
+	if exponent == -6 {
+		return "μ"
+	}
+
+	for symbol, exp := range EngineeringSymbols {
+		if exp == exponent {
+			return symbol
+		}
+	}
+
+	return ""
+}
+
+// SplitEngineeringSymbol splits a number literal such as "4.7k" into the number itself and
+// its engineering symbol. If the literal has no symbol, the symbol returned is empty.
+func SplitEngineeringSymbol(literal string) (string, string) {
+	for symbol := range EngineeringSymbols {
+		if strings.HasSuffix(literal, symbol) {
+			return strings.TrimSuffix(literal, symbol), symbol
+		}
+	}
+
+	return literal, ""
+}